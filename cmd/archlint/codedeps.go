@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/archlint"
+	"github.com/PET-dev-projects/ArchLint/pkg/codedeps"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+func runCodeDeps(args []string) error {
+	fs := flag.NewFlagSet("code-deps", flag.ContinueOnError)
+	file := fs.String("f", "", "path to architecture YAML file")
+	configPath := fs.String("config", "", "YAML file mapping Go package patterns to containers")
+	root := fs.String("root", "", "directory to scan (overrides the config's root)")
+	tests := fs.Bool("tests", false, "also read _test.go files")
+	format := fs.String("format", "text", "output format: text, json or sarif")
+	failOn := fs.String("fail-on", "error", "fail on severity: error|warn|info|none")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" || *configPath == "" {
+		return errors.New("-f and -config are required")
+	}
+
+	arch, err := archlint.LoadModelFromFile(*file)
+	if err != nil {
+		return err
+	}
+	cfg, err := codedeps.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	if *root != "" {
+		cfg.Root = *root
+	}
+	if *tests {
+		cfg.IncludeTests = true
+	}
+
+	findings, err := codedeps.Check(arch, cfg)
+	if err != nil {
+		return err
+	}
+	return writeFindings(*format, *failOn, findings, findings, []types.RuleMetadata{codedeps.RuleMetadata()})
+}
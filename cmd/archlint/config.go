@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/config"
+)
+
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "validate" {
+		return errors.New("usage: archlint config validate -f <rules.yaml>")
+	}
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	file := fs.String("f", "", "path to the rule configuration YAML file")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+
+	problems, err := config.ValidateFile(*file)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Fprintf(os.Stdout, "%s:%s\n", *file, p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) in %s", len(problems), *file)
+	}
+	fmt.Fprintf(os.Stdout, "%s: OK\n", *file)
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/archlint"
+	"github.com/PET-dev-projects/ArchLint/pkg/drift"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+func runDrift(args []string) error {
+	fs := flag.NewFlagSet("drift", flag.ContinueOnError)
+	file := fs.String("f", "", "path to architecture YAML file")
+	observed := fs.String("observed", "", "observed call edges: OTLP/JSON trace export or caller,callee,protocol CSV")
+	input := fs.String("input", "auto", "observed file format: auto, otlp or csv")
+	mappingPath := fs.String("map", "", "YAML file mapping telemetry service names to containers")
+	format := fs.String("format", "text", "output format: text, json or sarif")
+	failOn := fs.String("fail-on", "error", "fail on severity: error|warn|info|none")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" || *observed == "" {
+		return errors.New("-f and -observed are required")
+	}
+
+	arch, err := archlint.LoadModelFromFile(*file)
+	if err != nil {
+		return err
+	}
+
+	var mapping drift.Mapping
+	if *mappingPath != "" {
+		fh, err := os.Open(*mappingPath)
+		if err != nil {
+			return err
+		}
+		mapping, err = drift.LoadMapping(fh)
+		fh.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", *mappingPath, err)
+		}
+	}
+
+	if *input == "auto" {
+		*input = "otlp"
+		if strings.EqualFold(filepath.Ext(*observed), ".csv") {
+			*input = "csv"
+		}
+	}
+	fh, err := os.Open(*observed)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	var edges []drift.Edge
+	switch *input {
+	case "otlp":
+		edges, err = drift.LoadOTLP(fh)
+	case "csv":
+		edges, err = drift.LoadCSV(fh)
+	default:
+		return fmt.Errorf("unknown input format %s", *input)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", *observed, err)
+	}
+
+	findings := drift.Compare(arch, edges, mapping)
+	return writeFindings(*format, *failOn, findings, findings, []types.RuleMetadata{drift.RuleMetadata()})
+}
@@ -4,13 +4,22 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/PET-dev-projects/ArchLint/pkg/archlint"
+	"github.com/PET-dev-projects/ArchLint/pkg/baseline"
+	"github.com/PET-dev-projects/ArchLint/pkg/compose"
 	"github.com/PET-dev-projects/ArchLint/pkg/config"
+	"github.com/PET-dev-projects/ArchLint/pkg/diff"
 	"github.com/PET-dev-projects/ArchLint/pkg/engine"
+	"github.com/PET-dev-projects/ArchLint/pkg/kubernetes"
+	"github.com/PET-dev-projects/ArchLint/pkg/metrics"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/render"
 	"github.com/PET-dev-projects/ArchLint/pkg/report"
+	"github.com/PET-dev-projects/ArchLint/pkg/structurizr"
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
@@ -27,6 +36,56 @@ func main() {
 			fmt.Fprintln(os.Stderr, "Error:", err)
 			os.Exit(1)
 		}
+	case "baseline":
+		if err := runBaseline(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "diff":
+		if err := runDiff(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "metrics":
+		if err := runMetrics(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "drift":
+		if err := runDrift(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "code-deps":
+		if err := runCodeDeps(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "graph":
+		if err := runGraph(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "import":
+		if err := runImport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "export":
+		if err := runExport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "rules":
+		if err := runRules(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	case "config":
+		if err := runConfig(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
 	case "help", "-h", "--help":
 		usage()
 	default:
@@ -39,9 +98,11 @@ func main() {
 func runCheck(args []string) error {
 	fs := flag.NewFlagSet("check", flag.ContinueOnError)
 	file := fs.String("f", "", "path to architecture YAML file")
-	format := fs.String("format", "text", "output format: text or json")
+	format := fs.String("format", "text", "output format: text, json or sarif")
 	failOn := fs.String("fail-on", "error", "fail on severity: error|warn|info|none")
 	configPath := fs.String("config", "", "YAML file describing enabled rules and their configs")
+	since := fs.String("since", "", "baseline architecture YAML; report only findings not present there")
+	baselinePath := fs.String("baseline", "", "baseline file of accepted findings (see 'archlint baseline write')")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -58,52 +119,357 @@ func runCheck(args []string) error {
 		opts = loaded
 	}
 
-	fh, err := os.Open(*file)
+	arch, findings, err := evaluate(*file, opts)
 	if err != nil {
 		return err
 	}
-	defer fh.Close()
+	if *since != "" {
+		prev, prevFindings, err := evaluate(*since, opts)
+		if err != nil {
+			return err
+		}
+		findings = baseline.Since(prev, prevFindings, arch, findings)
+	}
+	failing := findings
+	if *baselinePath != "" {
+		accepted, err := baseline.Load(*baselinePath)
+		if err != nil {
+			return err
+		}
+		res := accepted.Apply(arch, findings)
+		failing = res.New
+		findings = append(res.New, res.StaleFindings()...)
+	}
+
+	return writeFindings(*format, *failOn, findings, failing, archlint.RuleMetadataFor(opts))
+}
+
+func runBaseline(args []string) error {
+	if len(args) == 0 || args[0] != "write" {
+		return errors.New("usage: archlint baseline write -f <arch.yaml> [-o <baseline.json>]")
+	}
+	fs := flag.NewFlagSet("baseline write", flag.ContinueOnError)
+	file := fs.String("f", "", "path to architecture YAML file")
+	out := fs.String("o", ".archlint-baseline.json", "baseline file to write")
+	configPath := fs.String("config", "", "YAML file describing enabled rules and their configs")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+
+	var opts engine.Options
+	if *configPath != "" {
+		loaded, err := config.LoadOptionsFromFile(*configPath)
+		if err != nil {
+			return err
+		}
+		opts = loaded
+	}
 
-	model, err := archlint.LoadModelFromYAML(fh)
+	arch, findings, err := evaluate(*file, opts)
 	if err != nil {
 		return err
 	}
 
+	accepted := baseline.New(arch, findings)
+	if err := writeOutput(*out, accepted.Write); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Recorded %d findings in %s\n", len(accepted.Entries), *out)
+	return nil
+}
+
+func evaluate(path string, opts engine.Options) (*model.Architecture, []types.Finding, error) {
+	arch, err := archlint.LoadModelFromFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
 	findings := make([]types.Finding, 0)
-	findings = append(findings, archlint.ValidateModel(model)...)
-	findings = append(findings, archlint.RunAll(model, opts)...)
+	findings = append(findings, archlint.ValidateModel(arch)...)
+	findings = append(findings, archlint.RunAll(arch, opts)...)
+	return arch, findings, nil
+}
 
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	oldFile := fs.String("old", "", "path to the previous architecture YAML file")
+	newFile := fs.String("new", "", "path to the updated architecture YAML file")
+	format := fs.String("format", "text", "output format: text, json or markdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *oldFile == "" || *newFile == "" {
+		return errors.New("-old and -new are required")
+	}
+
+	prev, err := archlint.LoadModelFromFile(*oldFile)
+	if err != nil {
+		return err
+	}
+	next, err := archlint.LoadModelFromFile(*newFile)
+	if err != nil {
+		return err
+	}
+
+	result := diff.Compare(prev, next)
 	switch *format {
 	case "text":
-		if err := report.WriteText(os.Stdout, findings); err != nil {
-			return err
-		}
+		return diff.WriteText(os.Stdout, result)
 	case "json":
-		if err := report.WriteJSON(os.Stdout, findings); err != nil {
-			return err
-		}
+		return diff.WriteJSON(os.Stdout, result)
+	case "markdown":
+		return diff.WriteMarkdown(os.Stdout, result)
 	default:
 		return fmt.Errorf("unknown format %s", *format)
 	}
+}
 
-	if shouldFail(findings, *failOn) {
-		return errors.New("fail-on threshold reached")
+func runMetrics(args []string) error {
+	fs := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	file := fs.String("f", "", "path to architecture YAML file")
+	format := fs.String("format", "json", "output format: json, csv or prometheus")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
 	}
 
-	return nil
+	arch, err := archlint.LoadModelFromFile(*file)
+	if err != nil {
+		return err
+	}
+
+	result := metrics.Collect(arch)
+	switch *format {
+	case "json":
+		return metrics.WriteJSON(os.Stdout, result)
+	case "csv":
+		return metrics.WriteCSV(os.Stdout, result)
+	case "prometheus":
+		return metrics.WritePrometheus(os.Stdout, result)
+	default:
+		return fmt.Errorf("unknown format %s", *format)
+	}
+}
+
+func runGraph(args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	file := fs.String("f", "", "path to architecture YAML file")
+	format := fs.String("format", "dot", "output format: dot, mermaid or plantuml")
+	highlight := fs.Bool("highlight", false, "highlight containers and relations with findings")
+	configPath := fs.String("config", "", "YAML file describing enabled rules and their configs (with -highlight)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+
+	var opts engine.Options
+	if *configPath != "" {
+		loaded, err := config.LoadOptionsFromFile(*configPath)
+		if err != nil {
+			return err
+		}
+		opts = loaded
+	}
+
+	arch, findings, err := evaluate(*file, opts)
+	if err != nil {
+		return err
+	}
+	var renderOpts render.Options
+	if *highlight {
+		renderOpts.Findings = findings
+	}
+	return render.Write(os.Stdout, render.Format(*format), arch, renderOpts)
+}
+
+const importUsage = "usage: archlint import structurizr|compose|kubernetes -f <file or dir> [-o <arch.yaml>]"
+
+// importSources describes the -f flag of each importer.
+var importSources = map[string]string{
+	"structurizr": "path to the Structurizr DSL workspace",
+	"compose":     "path to the docker-compose file",
+	"kubernetes":  "directory (or single file) of Kubernetes manifests",
+}
+
+func runImport(args []string) error {
+	if len(args) == 0 || importSources[args[0]] == "" {
+		return errors.New(importUsage)
+	}
+	fs := flag.NewFlagSet("import "+args[0], flag.ContinueOnError)
+	file := fs.String("f", "", importSources[args[0]])
+	out := fs.String("o", "", "architecture YAML file to write (default stdout)")
+	var name, boundaryLabel *string
+	switch args[0] {
+	case "compose":
+		name = fs.String("name", "", "boundary name (default: compose project name)")
+	case "kubernetes":
+		boundaryLabel = fs.String("boundary-label", "", "label grouping workloads into boundaries (default: namespace)")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+
+	var arch *model.Architecture
+	var warnings []string
+	var err error
+	switch args[0] {
+	case "structurizr":
+		arch, warnings, err = importFile(*file, func(r io.Reader) (*model.Architecture, []string, error) {
+			imported, ws, err := structurizr.Import(r)
+			return imported, fileWarnings(*file, ws), err
+		})
+	case "compose":
+		arch, warnings, err = importFile(*file, func(r io.Reader) (*model.Architecture, []string, error) {
+			imported, ws, err := compose.Import(r, compose.Options{Name: *name})
+			return imported, fileWarnings(*file, ws), err
+		})
+	case "kubernetes":
+		var ws []kubernetes.Warning
+		arch, ws, err = kubernetes.ImportDir(*file, kubernetes.Options{BoundaryLabel: *boundaryLabel})
+		// Kubernetes warnings already name the manifest they come from.
+		warnings = fileWarnings("", ws)
+	}
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", w)
+	}
+	return writeOutput(*out, func(w io.Writer) error { return model.WriteYAML(w, arch) })
+}
+
+func importFile(path string, read func(io.Reader) (*model.Architecture, []string, error)) (*model.Architecture, []string, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fh.Close()
+	return read(fh)
+}
+
+// fileWarnings renders importer warnings, prefixed with file when set.
+func fileWarnings[W fmt.Stringer](file string, ws []W) []string {
+	out := make([]string, 0, len(ws))
+	for _, w := range ws {
+		if file == "" {
+			out = append(out, w.String())
+		} else {
+			out = append(out, file+": "+w.String())
+		}
+	}
+	return out
+}
+
+func runExport(args []string) error {
+	if len(args) == 0 || args[0] != "structurizr" {
+		return errors.New("usage: archlint export structurizr -f <arch.yaml> [-o <workspace.dsl>]")
+	}
+	fs := flag.NewFlagSet("export "+args[0], flag.ContinueOnError)
+	file := fs.String("f", "", "path to architecture YAML file")
+	out := fs.String("o", "", "file to write (default stdout)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+
+	arch, err := archlint.LoadModelFromFile(*file)
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, func(w io.Writer) error {
+		warnings, err := structurizr.Export(w, arch)
+		for _, warning := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", warning)
+		}
+		return err
+	})
+}
+
+func writeOutput(path string, write func(io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+	fh, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(fh); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
 }
 
 func usage() {
 	fmt.Fprintf(os.Stderr, `Usage: archlint <command> [options]
 
 Commands:
-  check   Run architecture checks
+  check           Run architecture checks
+  baseline write  Record current findings as accepted
+  diff            Show semantic changes between two architecture models
+  metrics         Print architecture statistics as JSON, CSV or Prometheus text
+  drift           Compare the model with observed traffic (OTLP JSON or CSV)
+  code-deps       Check Go imports between containers against declared relations
+  graph           Render the model as Graphviz DOT, Mermaid or PlantUML
+  import          Convert another format into architecture YAML (structurizr, compose, kubernetes)
+  export          Convert architecture YAML into another format (structurizr)
+  rules list      List available rules
+  rules explain   Describe a rule, its rationale and configuration
+  config validate Check a rule configuration file for mistakes
 
 Examples:
   archlint check -f examples/payments.yaml --config configs/rules.yaml
+  archlint baseline write -f examples/payments.yaml -o .archlint-baseline.json
+  archlint check -f examples/payments.yaml -baseline .archlint-baseline.json
+  archlint diff -old old.yaml -new examples/payments.yaml -format markdown
+  archlint metrics -f examples/music_streaming.yaml -format prometheus
+  archlint drift -f examples/payments.yaml -observed edges.csv -map telemetry-names.yaml
+  archlint code-deps -f arch.yaml -config archlint-code.yaml
+  archlint graph -f examples/payments.yaml -format mermaid -highlight
+  archlint export structurizr -f examples/payments.yaml -o workspace.dsl
+  archlint import structurizr -f workspace.dsl -o arch.yaml
+  archlint import compose -f docker-compose.yml -o arch.yaml
+  archlint import kubernetes -f deploy/ -boundary-label app.kubernetes.io/part-of
+  archlint rules explain ARCH-CRUD
+  archlint config validate -f configs/rules.yaml
 `)
 }
 
+// writeFindings prints findings to stdout in format and returns an error
+// when a failing finding reaches the failOn severity.
+func writeFindings(format, failOn string, findings, failing []types.Finding, rules []types.RuleMetadata) error {
+	var err error
+	switch format {
+	case "text":
+		err = report.WriteText(os.Stdout, findings)
+	case "json":
+		err = report.WriteJSON(os.Stdout, findings)
+	case "sarif":
+		err = report.WriteSARIF(os.Stdout, findings, rules)
+	default:
+		return fmt.Errorf("unknown format %s", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if shouldFail(failing, failOn) {
+		return errors.New("fail-on threshold reached")
+	}
+	return nil
+}
+
 func shouldFail(findings []types.Finding, failOn string) bool {
 	failOn = strings.ToLower(failOn)
 	severityOrder := map[string]int{
@@ -118,6 +484,9 @@ func shouldFail(findings []types.Finding, failOn string) bool {
 	}
 
 	for _, f := range findings {
+		if f.Suppressed {
+			continue
+		}
 		if severityOrder[strings.ToLower(string(f.Severity))] >= threshold {
 			return true
 		}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/archlint"
+	"github.com/PET-dev-projects/ArchLint/pkg/codedeps"
+	"github.com/PET-dev-projects/ArchLint/pkg/config"
+	"github.com/PET-dev-projects/ArchLint/pkg/drift"
+	"github.com/PET-dev-projects/ArchLint/pkg/engine"
+	"github.com/PET-dev-projects/ArchLint/pkg/report"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+const rulesUsage = "usage: archlint rules list [-format text|json] | archlint rules explain <RULE-ID>"
+
+func runRules(args []string) error {
+	if len(args) == 0 {
+		return errors.New(rulesUsage)
+	}
+	fs := flag.NewFlagSet("rules "+args[0], flag.ContinueOnError)
+	format := fs.String("format", "text", "output format: text or json")
+	configPath := fs.String("config", "", "YAML file with custom rules to include")
+
+	switch args[0] {
+	case "list":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		rules, err := ruleMetadata(*configPath)
+		if err != nil {
+			return err
+		}
+		switch *format {
+		case "text":
+			return report.WriteRuleList(os.Stdout, rules)
+		case "json":
+			return report.WriteRulesJSON(os.Stdout, rules)
+		default:
+			return fmt.Errorf("unknown format %s", *format)
+		}
+	case "explain":
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return errors.New(rulesUsage)
+		}
+		rules, err := ruleMetadata(*configPath)
+		if err != nil {
+			return err
+		}
+		id := fs.Arg(0)
+		for _, rule := range rules {
+			if !strings.EqualFold(rule.ID, id) {
+				continue
+			}
+			switch *format {
+			case "text":
+				return report.WriteRuleDetails(os.Stdout, rule)
+			case "json":
+				return report.WriteRulesJSON(os.Stdout, []types.RuleMetadata{rule})
+			default:
+				return fmt.Errorf("unknown format %s", *format)
+			}
+		}
+		return fmt.Errorf("unknown rule %s (see 'archlint rules list')", id)
+	default:
+		return errors.New(rulesUsage)
+	}
+}
+
+func ruleMetadata(configPath string) ([]types.RuleMetadata, error) {
+	var opts engine.Options
+	if configPath != "" {
+		loaded, err := config.LoadOptionsFromFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+		opts = loaded
+	}
+	return append(archlint.RuleMetadataFor(opts), drift.RuleMetadata(), codedeps.RuleMetadata()), nil
+}
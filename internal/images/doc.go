@@ -0,0 +1,3 @@
+// Package images recognises well-known container images so the importers
+// classify databases and brokers the same way.
+package images
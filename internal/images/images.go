@@ -0,0 +1,45 @@
+package images
+
+import "strings"
+
+// databases maps image names to the technology of database containers.
+var databases = map[string]string{
+	"postgres":      "postgres",
+	"postgis":       "postgres",
+	"mysql":         "mysql",
+	"mariadb":       "mariadb",
+	"redis":         "redis",
+	"mongo":         "mongodb",
+	"mongodb":       "mongodb",
+	"elasticsearch": "elasticsearch",
+	"cassandra":     "cassandra",
+}
+
+// brokers maps image names to the technology of message brokers.
+var brokers = map[string]string{
+	"kafka":    "kafka",
+	"rabbitmq": "rabbitmq",
+	"nats":     "nats",
+}
+
+// Name strips registry, namespace, tag and digest: "docker.io/library/postgres:16" -> "postgres".
+func Name(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if idx := strings.LastIndex(image, "/"); idx >= 0 {
+		image = image[idx+1:]
+	}
+	image, _, _ = strings.Cut(image, ":")
+	return strings.ToLower(image)
+}
+
+// Database returns the technology of a database image.
+func Database(image string) (string, bool) {
+	tech, ok := databases[Name(image)]
+	return tech, ok
+}
+
+// Broker returns the technology of a message broker image.
+func Broker(image string) (string, bool) {
+	tech, ok := brokers[Name(image)]
+	return tech, ok
+}
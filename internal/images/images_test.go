@@ -0,0 +1,22 @@
+package images_test
+
+import (
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/internal/images"
+)
+
+func TestClassify(t *testing.T) {
+	if got := images.Name("registry.example.com:5000/library/Postgres:16@sha256:abc"); got != "postgres" {
+		t.Fatalf("unexpected image name %q", got)
+	}
+	if tech, ok := images.Database("bitnami/mongodb:7"); !ok || tech != "mongodb" {
+		t.Fatalf("expected mongodb database, got %q %v", tech, ok)
+	}
+	if tech, ok := images.Broker("confluentinc/kafka"); !ok || tech != "kafka" {
+		t.Fatalf("expected kafka broker, got %q %v", tech, ok)
+	}
+	if _, ok := images.Database("nginx:1.27"); ok {
+		t.Fatal("nginx is not a database")
+	}
+}
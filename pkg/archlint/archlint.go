@@ -16,6 +16,12 @@ func LoadModelFromYAML(r io.Reader) (*model.Architecture, error) {
 	return model.LoadModelFromYAML(r)
 }
 
+// LoadModelFromFile reads architecture YAML from path, keeping the file name
+// in finding locations.
+func LoadModelFromFile(path string) (*model.Architecture, error) {
+	return model.LoadModelFromFile(path)
+}
+
 // ValidateModel runs structural validation.
 func ValidateModel(m *model.Architecture) []types.Finding {
 	return model.ValidateModel(m)
@@ -25,3 +31,14 @@ func ValidateModel(m *model.Architecture) []types.Finding {
 func RunAll(m *model.Architecture, opts Options) []types.Finding {
 	return engine.RunAll(m, opts)
 }
+
+// RuleMetadata describes structural validation plus every built-in rule.
+func RuleMetadata() []types.RuleMetadata {
+	return RuleMetadataFor(Options{})
+}
+
+// RuleMetadataFor describes structural validation plus every rule opts can
+// run, custom rules included.
+func RuleMetadataFor(opts Options) []types.RuleMetadata {
+	return append([]types.RuleMetadata{model.ValidationRuleMetadata()}, opts.RuleMetadata()...)
+}
@@ -0,0 +1,173 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// FileVersion is the baseline file format version written by this package.
+// Version 2 stopped hashing finding messages.
+const FileVersion = 2
+
+const staleRuleID = "BASELINE-STALE"
+
+// File is the on-disk list of accepted findings.
+type File struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry records one accepted finding. Key, Class and Message are kept for
+// humans reviewing the file; matching only uses Fingerprint.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"ruleId"`
+	Key         string `json:"key"`
+	Class       string `json:"class,omitempty"`
+	Message     string `json:"message"`
+}
+
+// Result splits findings according to a baseline.
+type Result struct {
+	New        []types.Finding
+	Suppressed []types.Finding
+	Stale      []Entry
+}
+
+// Fingerprint returns a stable identifier for f within m. It combines the rule
+// ID, the name-based key of the offending element and the message class. The
+// message is left out because several rules put computed numbers in it, and
+// those change whenever an unrelated relation does.
+func Fingerprint(m *model.Architecture, f types.Finding) string {
+	sum := sha256.Sum256([]byte(f.RuleID + "\x00" + stableKey(m, f) + "\x00" + f.Class))
+	return hex.EncodeToString(sum[:8])
+}
+
+// New builds a baseline accepting every finding not already suppressed inline.
+func New(m *model.Architecture, findings []types.Finding) File {
+	entries := make([]Entry, 0, len(findings))
+	for _, f := range findings {
+		if f.Suppressed {
+			continue
+		}
+		entries = append(entries, Entry{
+			Fingerprint: Fingerprint(m, f),
+			RuleID:      f.RuleID,
+			Key:         stableKey(m, f),
+			Class:       f.Class,
+			Message:     f.Message,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].RuleID != entries[j].RuleID {
+			return entries[i].RuleID < entries[j].RuleID
+		}
+		if entries[i].Key != entries[j].Key {
+			return entries[i].Key < entries[j].Key
+		}
+		if entries[i].Class != entries[j].Class {
+			return entries[i].Class < entries[j].Class
+		}
+		return entries[i].Message < entries[j].Message
+	})
+	return File{Version: FileVersion, Entries: entries}
+}
+
+// Load reads a baseline file from path.
+func Load(path string) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, err
+	}
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return File{}, fmt.Errorf("baseline %s: %w", path, err)
+	}
+	if file.Version != FileVersion {
+		return File{}, fmt.Errorf("baseline %s: unsupported version %d (only version %d is supported)", path, file.Version, FileVersion)
+	}
+	return file, nil
+}
+
+// Write serializes the baseline as indented JSON.
+func (b File) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(b)
+}
+
+// Apply matches findings against the baseline. Each entry absorbs at most one
+// finding; entries left unmatched are returned as stale. Inline-suppressed
+// findings pass through to New untouched.
+func (b File) Apply(m *model.Architecture, findings []types.Finding) Result {
+	pending := map[string][]int{}
+	for idx, entry := range b.Entries {
+		pending[entry.Fingerprint] = append(pending[entry.Fingerprint], idx)
+	}
+
+	res := Result{
+		New:        make([]types.Finding, 0),
+		Suppressed: make([]types.Finding, 0),
+		Stale:      make([]Entry, 0),
+	}
+	for _, f := range findings {
+		if f.Suppressed {
+			res.New = append(res.New, f)
+			continue
+		}
+		fp := Fingerprint(m, f)
+		if idxs := pending[fp]; len(idxs) > 0 {
+			pending[fp] = idxs[1:]
+			res.Suppressed = append(res.Suppressed, f)
+			continue
+		}
+		res.New = append(res.New, f)
+	}
+	for _, entry := range b.Entries {
+		if idxs := pending[entry.Fingerprint]; len(idxs) > 0 {
+			pending[entry.Fingerprint] = idxs[1:]
+			res.Stale = append(res.Stale, entry)
+		}
+	}
+	return res
+}
+
+// StaleFindings reports stale entries as informational findings.
+func (r Result) StaleFindings() []types.Finding {
+	findings := make([]types.Finding, 0, len(r.Stale))
+	for _, entry := range r.Stale {
+		findings = append(findings, types.Finding{
+			RuleID:   staleRuleID,
+			Severity: types.SeverityInfo,
+			Message:  fmt.Sprintf("baseline entry %s for %s no longer matches a finding: %s", entry.Fingerprint, entry.RuleID, entry.Message),
+			Path:     entry.Key,
+			Meta: map[string]any{
+				"fingerprint": entry.Fingerprint,
+				"ruleId":      entry.RuleID,
+			},
+		})
+	}
+	return findings
+}
+
+// Since returns the findings of current that have no counterpart among the
+// findings produced for the previous model revision.
+func Since(prev *model.Architecture, prevFindings []types.Finding, current *model.Architecture, currentFindings []types.Finding) []types.Finding {
+	return New(prev, prevFindings).Apply(current, currentFindings).New
+}
+
+func stableKey(m *model.Architecture, f types.Finding) string {
+	if m == nil {
+		return f.Path
+	}
+	return m.StableKey(f.Path)
+}
@@ -0,0 +1,142 @@
+package baseline_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/baseline"
+	"github.com/PET-dev-projects/ArchLint/pkg/engine"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+func TestSinceIgnoresReordering(t *testing.T) {
+	prev := aclModel(
+		model.Relation{From: "api", To: "repo", Kind: model.RelationKindSync},
+		model.Relation{From: "api", To: "audit", Kind: model.RelationKindSync, Protocol: "https://gateway.example/audit"},
+	)
+	current := aclModel(
+		model.Relation{From: "worker", To: "audit", Kind: model.RelationKindAsync, Protocol: "kafka://audit"},
+		model.Relation{From: "api", To: "audit", Kind: model.RelationKindSync, Protocol: "https://gateway.example/audit"},
+		model.Relation{From: "api", To: "repo", Kind: model.RelationKindSync},
+	)
+	opts := engine.Options{EnabledRules: []string{"ARCH-ACL"}}
+
+	prevFindings := engine.RunAll(prev, opts)
+	currentFindings := engine.RunAll(current, opts)
+	if len(prevFindings) != 1 || len(currentFindings) != 2 {
+		t.Fatalf("unexpected fixture findings: %v / %v", prevFindings, currentFindings)
+	}
+
+	fresh := baseline.Since(prev, prevFindings, current, currentFindings)
+	if len(fresh) != 1 || fresh[0].Path != "boundaries[0].relations[0]" {
+		t.Fatalf("expected only the worker relation to be new, got %v", fresh)
+	}
+}
+
+func TestFileApply(t *testing.T) {
+	opts := engine.Options{EnabledRules: []string{"ARCH-ACL"}}
+	recorded := aclModel(
+		model.Relation{From: "api", To: "audit", Kind: model.RelationKindSync},
+		model.Relation{From: "repo", To: "audit", Kind: model.RelationKindSync},
+	)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	fh, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := baseline.New(recorded, engine.RunAll(recorded, opts)).Write(fh); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	fh.Close()
+
+	accepted, err := baseline.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(accepted.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", accepted.Entries)
+	}
+
+	current := aclModel(
+		model.Relation{From: "worker", To: "audit", Kind: model.RelationKindSync},
+		model.Relation{From: "api", To: "audit", Kind: model.RelationKindSync},
+	)
+	res := accepted.Apply(current, engine.RunAll(current, opts))
+	if len(res.New) != 1 || res.New[0].Path != "boundaries[0].relations[0]" {
+		t.Fatalf("expected worker relation to be new, got %v", res.New)
+	}
+	if len(res.Suppressed) != 1 {
+		t.Fatalf("expected api relation to be suppressed, got %v", res.Suppressed)
+	}
+	if len(res.Stale) != 1 || res.Stale[0].Key != "relation:repo->audit[sync]" {
+		t.Fatalf("expected repo relation entry to be stale, got %+v", res.Stale)
+	}
+	if stale := res.StaleFindings(); len(stale) != 1 || stale[0].RuleID != "BASELINE-STALE" {
+		t.Fatalf("unexpected stale findings: %v", stale)
+	}
+}
+
+func TestFileApplyIgnoresMessageChanges(t *testing.T) {
+	opts := engine.Options{
+		EnabledRules: []string{"ARCH-COUPLING"},
+		RuleConfig:   map[string]map[string]any{"ARCH-COUPLING": {"maxFanOut": 1}},
+	}
+	recorded := aclModel(
+		model.Relation{From: "api", To: "worker", Kind: model.RelationKindSync},
+		model.Relation{From: "api", To: "repo", Kind: model.RelationKindSync},
+	)
+	accepted := baseline.New(recorded, engine.RunAll(recorded, opts))
+	if len(accepted.Entries) != 1 || accepted.Entries[0].Class != "fan-out" {
+		t.Fatalf("expected one fan-out entry, got %+v", accepted.Entries)
+	}
+
+	current := aclModel(
+		model.Relation{From: "api", To: "worker", Kind: model.RelationKindSync},
+		model.Relation{From: "api", To: "repo", Kind: model.RelationKindSync},
+		model.Relation{From: "api", To: "audit", Kind: model.RelationKindSync},
+	)
+	findings := engine.RunAll(current, opts)
+	if len(findings) != 1 || findings[0].Message == accepted.Entries[0].Message {
+		t.Fatalf("expected the fan-out message to change, got %v", findings)
+	}
+	res := accepted.Apply(current, findings)
+	if len(res.New) != 0 || len(res.Suppressed) != 1 || len(res.Stale) != 0 {
+		t.Fatalf("a changed count must not turn an accepted finding into a new one: %+v", res)
+	}
+}
+
+func TestStableKey(t *testing.T) {
+	m := aclModel(model.Relation{From: "api", To: "audit", Kind: model.RelationKindSync})
+	m.Externals[0].Provides = []model.Interface{{Name: "record", Kind: model.InterfaceHTTP}}
+	cases := map[string]string{
+		"boundaries[0]":                       "boundary:Core",
+		"boundaries[0].containers[1].name":    "container:worker.name",
+		"boundaries[0].relations[0].protocol": "relation:api->audit[sync].protocol",
+		"externals[0]":                        "container:audit",
+		"externals[0].provides[0].kind":       "container:audit.provides:record.kind",
+		"options.ruleConfig[ARCH-ACL]":        "options.ruleConfig[ARCH-ACL]",
+	}
+	for path, want := range cases {
+		if got := m.StableKey(path); got != want {
+			t.Fatalf("StableKey(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func aclModel(relations ...model.Relation) *model.Architecture {
+	return &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{{
+			Name: "Core",
+			Containers: []model.Container{
+				{Name: "api", Type: model.ContainerService},
+				{Name: "worker", Type: model.ContainerService},
+				{Name: "repo", Type: model.ContainerService},
+			},
+			Relations: relations,
+		}},
+		Externals: []model.Container{{Name: "audit", Type: model.ContainerExternal}},
+	}
+}
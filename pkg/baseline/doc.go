@@ -0,0 +1,5 @@
+// Package baseline matches findings across model revisions using fingerprints
+// derived from rule IDs and element names instead of list-index paths, so
+// inserting or reordering boundaries and relations does not resurface
+// findings that were already known.
+package baseline
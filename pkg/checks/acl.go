@@ -7,12 +7,17 @@ import (
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-const aclRuleID = "ARCH-ACL"
+const (
+	aclRuleID = "ARCH-ACL"
+
+	// Message classes, usable as severity override keys.
+	classACLExternalCall = "external-call"
+)
 
 type aclRule struct{}
 
 type aclConfig struct {
-	AllowedTags []string `json:"allowedTags"`
+	AllowedTags []string `json:"allowedTags" doc:"Tags that allow a container to call external systems."`
 }
 
 var defaultACLConfig = aclConfig{
@@ -24,6 +29,18 @@ func NewACLRule() Rule { return &aclRule{} }
 
 func (r *aclRule) ID() string { return aclRuleID }
 
+func (r *aclRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              aclRuleID,
+		Description:     "Only ACL-tagged containers may call external systems.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "External systems change on their own schedule; routing calls through anti-corruption layers keeps their models and failures out of the domain.",
+		DocURL:          types.RuleDocURL(aclRuleID),
+		Classes:         []string{classACLExternalCall},
+		Config:          configSchema(defaultACLConfig),
+	}
+}
+
 func (r *aclRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
 	conf := defaultACLConfig
 	if err := decodeConfig(cfg, &conf); err != nil {
@@ -47,7 +64,9 @@ func (r *aclRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding
 					RuleID:   aclRuleID,
 					Severity: types.SeverityError,
 					Message:  fmt.Sprintf("container %s must declare one of %v to talk to external %s", from.Container.Name, conf.AllowedTags, to.Container.Name),
+					Class:    classACLExternalCall,
 					Path:     relRef.Path,
+					Location: relRef.Location(),
 				})
 			}
 		}
@@ -2,18 +2,25 @@ package checks
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/PET-dev-projects/ArchLint/pkg/model"
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-const acyclicRuleID = "ARCH-ACYCLIC"
+const (
+	acyclicRuleID = "ARCH-ACYCLIC"
+
+	// Message classes, usable as severity override keys.
+	classAcyclicCycle = "cycle"
+)
 
 type acyclicRule struct{}
 
 type acyclicConfig struct {
-	AllowedKinds     []model.RelationKind `json:"allowedKinds"`
-	IgnoreContainers []string             `json:"ignoreContainers"`
+	AllowedKinds      []model.RelationKind `json:"allowedKinds" doc:"Relation kinds followed when searching for cycles."`
+	IgnoreContainers  []string             `json:"ignoreContainers" doc:"Containers excluded from cycle detection."`
+	CrossBoundaryOnly bool                 `json:"crossBoundaryOnly" doc:"Only report cycles whose containers sit in more than one boundary."`
 }
 
 var defaultAcyclicConfig = acyclicConfig{
@@ -31,6 +38,18 @@ func NewAcyclicRule() Rule {
 
 func (r *acyclicRule) ID() string { return acyclicRuleID }
 
+func (r *acyclicRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              acyclicRuleID,
+		Description:     "Relations between containers must not form dependency cycles.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "Cycles couple deployments and let failures cascade; an acyclic dependency graph can be released and reasoned about one layer at a time.",
+		DocURL:          types.RuleDocURL(acyclicRuleID),
+		Classes:         []string{classAcyclicCycle},
+		Config:          configSchema(defaultAcyclicConfig),
+	}
+}
+
 func (r *acyclicRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
 	conf := defaultAcyclicConfig
 	if err := decodeConfig(cfg, &conf); err != nil {
@@ -41,73 +60,75 @@ func (r *acyclicRule) Run(m *model.Architecture, cfg map[string]any) []types.Fin
 	for _, kind := range conf.AllowedKinds {
 		allowedKind[kind] = struct{}{}
 	}
-	ignored := map[string]struct{}{}
-	for _, name := range conf.IgnoreContainers {
-		ignored[name] = struct{}{}
-	}
-
-	type edge struct {
-		to   string
-		path string
-	}
-
-	graph := map[string][]edge{}
-	for _, relRef := range m.Relations() {
-		rel := relRef.Relation
+	ignored := toStringSet(conf.IgnoreContainers)
+	follows := func(rel *model.Relation) bool {
 		if _, skip := ignored[rel.From]; skip {
-			continue
+			return false
+		}
+		if _, skip := ignored[rel.To]; skip {
+			return false
 		}
 		if len(allowedKind) > 0 {
 			if _, ok := allowedKind[rel.Kind]; !ok {
-				continue
+				return false
 			}
 		}
-		graph[rel.From] = append(graph[rel.From], edge{to: rel.To, path: relRef.Path})
+		return true
 	}
 
+	relations := m.Relations()
+	g := newDependencyGraph(relations, follows)
+	containerIndex := m.ContainerMap()
+
 	findings := make([]types.Finding, 0)
-	visited := map[string]bool{}
-	stack := make([]string, 0)
-	stackIdx := map[string]int{}
-
-	var visit func(node string)
-	visit = func(node string) {
-		visited[node] = true
-		stackIdx[node] = len(stack)
-		stack = append(stack, node)
-
-		for _, edge := range graph[node] {
-			if _, ignore := ignored[edge.to]; ignore {
-				continue
-			}
-			if idx, onStack := stackIdx[edge.to]; onStack {
-				cycle := append([]string{}, stack[idx:]...)
-				cycle = append(cycle, edge.to)
-				findings = append(findings, types.Finding{
-					RuleID:   acyclicRuleID,
-					Severity: types.SeverityError,
-					Message:  fmt.Sprintf("cycle detected: %v", cycle),
-					Path:     edge.path,
-					Meta: map[string]any{
-						"cycle": cycle,
-					},
-				})
+	for _, members := range g.cycles() {
+		inCycle := toStringSet(members)
+		if conf.CrossBoundaryOnly && !spansBoundaries(members, containerIndex) {
+			continue
+		}
+
+		var first *model.RelationRef
+		paths := make([]string, 0)
+		for i, relRef := range relations {
+			rel := relRef.Relation
+			_, fromIn := inCycle[rel.From]
+			_, toIn := inCycle[rel.To]
+			if !fromIn || !toIn || !follows(rel) {
 				continue
 			}
-			if !visited[edge.to] {
-				visit(edge.to)
+			if first == nil {
+				first = &relations[i]
 			}
+			paths = append(paths, relRef.Path)
 		}
 
-		stack = stack[:len(stack)-1]
-		delete(stackIdx, node)
-	}
-
-	for node := range graph {
-		if !visited[node] {
-			visit(node)
+		cycle := g.shortestCycle(members[0], inCycle)
+		message := fmt.Sprintf("cycle detected: %s", strings.Join(cycle, " -> "))
+		if len(members) > len(cycle)-1 {
+			message += fmt.Sprintf(" (part of %d mutually dependent containers: %s)", len(members), strings.Join(members, ", "))
 		}
+		findings = append(findings, types.Finding{
+			RuleID:   acyclicRuleID,
+			Severity: types.SeverityError,
+			Message:  message,
+			Class:    classAcyclicCycle,
+			Path:     first.Path,
+			Location: first.Location(),
+			Meta: map[string]any{
+				"cycle":     cycle,
+				"members":   members,
+				"relations": paths,
+			},
+		})
 	}
 
 	return findings
 }
+
+func spansBoundaries(members []string, containerIndex map[string]model.ContainerRef) bool {
+	boundaries := map[*model.Boundary]struct{}{}
+	for _, name := range members {
+		boundaries[containerIndex[name].Boundary] = struct{}{}
+	}
+	return len(boundaries) > 1
+}
@@ -2,22 +2,48 @@ package checks
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/PET-dev-projects/ArchLint/pkg/model"
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-const boundariesRuleID = "ARCH-BOUNDARIES"
+const (
+	boundariesRuleID = "ARCH-BOUNDARIES"
+
+	// Message classes, usable as severity override keys.
+	classBoundariesRatio = "ratio"
+	classBoundariesCross = "cross-relations"
+)
 
 type boundariesRule struct{}
 
+const (
+	boundariesOutbound = "outbound"
+	boundariesBoth     = "both"
+
+	// Boundary meta keys overriding the configured thresholds.
+	metaMinInternalToCrossRatio = "archlint.minInternalToCrossRatio"
+	metaMaxCrossRelations       = "archlint.maxCrossRelations"
+)
+
+type boundariesOverride struct {
+	Names                   []string `json:"names"`
+	Tags                    []string `json:"tags"`
+	MinInternalToCrossRatio *float64 `json:"minInternalToCrossRatio"`
+	MaxCrossRelations       *int     `json:"maxCrossRelations"`
+}
+
 type boundariesConfig struct {
-	MinInternalToCrossRatio float64 `json:"minInternalToCrossRatio"`
-	MaxCrossRelations       int     `json:"maxCrossRelations"`
+	MinInternalToCrossRatio float64              `json:"minInternalToCrossRatio" doc:"Minimum ratio of internal to cross-boundary relations."`
+	MaxCrossRelations       int                  `json:"maxCrossRelations" doc:"Maximum cross-boundary relations per boundary; 0 disables the limit."`
+	Direction               string               `json:"direction" doc:"Cross relations to count: outbound, or both to include relations entering the boundary."`
+	Overrides               []boundariesOverride `json:"overrides" doc:"Thresholds by boundary: {names, tags, minInternalToCrossRatio, maxCrossRelations}; the first match wins and unset values inherit."`
 }
 
 var defaultBoundariesConfig = boundariesConfig{
 	MinInternalToCrossRatio: 1,
+	Direction:               boundariesOutbound,
 }
 
 // NewBoundariesRule returns the cohesion/coupling rule implementation.
@@ -25,48 +51,84 @@ func NewBoundariesRule() Rule { return &boundariesRule{} }
 
 func (r *boundariesRule) ID() string { return boundariesRuleID }
 
+func (r *boundariesRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              boundariesRuleID,
+		Description:     "Boundaries should keep more internal than cross-boundary relations.",
+		DefaultSeverity: types.SeverityWarn,
+		Rationale:       "A boundary that talks more to its neighbours than within itself is probably drawn in the wrong place and will be hard to change independently.",
+		DocURL:          types.RuleDocURL(boundariesRuleID),
+		Classes:         []string{classBoundariesRatio, classBoundariesCross},
+		Config:          configSchema(defaultBoundariesConfig),
+	}
+}
+
 func (r *boundariesRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
 	conf := defaultBoundariesConfig
 	if err := decodeConfig(cfg, &conf); err != nil {
 		return []types.Finding{configFinding(boundariesRuleID, err)}
 	}
 
-	outgoing := buildOutgoing(m)
-	metrics := collectBoundaryMetrics(m, outgoing)
+	if conf.Direction != boundariesOutbound && conf.Direction != boundariesBoth {
+		return []types.Finding{configFinding(boundariesRuleID, fmt.Errorf("direction must be %q or %q, got %q", boundariesOutbound, boundariesBoth, conf.Direction))}
+	}
+
+	metrics := collectBoundaryMetrics(m)
 
 	findings := make([]types.Finding, 0)
 	for _, metric := range metrics {
-		if metric.cross == 0 {
+		minRatio, maxCross, err := conf.thresholdsFor(metric.boundary)
+		if err != nil {
+			findings = append(findings, types.Finding{
+				RuleID:   boundariesRuleID,
+				Severity: types.SeverityError,
+				Message:  fmt.Sprintf("boundary %s: %v", metric.name, err),
+				Class:    ClassConfig,
+				Path:     metric.path + ".meta",
+				Location: metric.location,
+			})
+			continue
+		}
+
+		cross := metric.cross
+		if conf.Direction == boundariesBoth {
+			cross += metric.inbound
+		}
+		if cross == 0 {
 			continue
 		}
 		ratio := 0.0
 		if metric.internal > 0 {
-			ratio = float64(metric.internal) / float64(metric.cross)
+			ratio = float64(metric.internal) / float64(cross)
 		}
 
-		if ratio < conf.MinInternalToCrossRatio {
+		if ratio < minRatio {
 			findings = append(findings, types.Finding{
 				RuleID:   boundariesRuleID,
 				Severity: types.SeverityWarn,
-				Message:  fmt.Sprintf("boundary %s cohesion/coupling ratio %.2f below minimum %.2f", metric.name, ratio, conf.MinInternalToCrossRatio),
+				Message:  fmt.Sprintf("boundary %s cohesion/coupling ratio %.2f below minimum %.2f", metric.name, ratio, minRatio),
+				Class:    classBoundariesRatio,
 				Path:     metric.path,
+				Location: metric.location,
 				Meta: map[string]any{
 					"internal": metric.internal,
-					"cross":    metric.cross,
+					"cross":    cross,
 					"ratio":    ratio,
 				},
 			})
 		}
 
-		if conf.MaxCrossRelations > 0 && metric.cross > conf.MaxCrossRelations {
+		if maxCross > 0 && cross > maxCross {
 			findings = append(findings, types.Finding{
 				RuleID:   boundariesRuleID,
 				Severity: types.SeverityWarn,
-				Message:  fmt.Sprintf("boundary %s has %d cross-boundary relations (max %d)", metric.name, metric.cross, conf.MaxCrossRelations),
+				Message:  fmt.Sprintf("boundary %s has %d cross-boundary relations (max %d)", metric.name, cross, maxCross),
+				Class:    classBoundariesCross,
 				Path:     metric.path,
+				Location: metric.location,
 				Meta: map[string]any{
 					"internal": metric.internal,
-					"cross":    metric.cross,
+					"cross":    cross,
 				},
 			})
 		}
@@ -75,27 +137,69 @@ func (r *boundariesRule) Run(m *model.Architecture, cfg map[string]any) []types.
 	return findings
 }
 
+// thresholdsFor resolves the thresholds of b: boundary meta wins over the
+// first matching override, which wins over the global values.
+func (c boundariesConfig) thresholdsFor(b *model.Boundary) (float64, int, error) {
+	minRatio, maxCross := c.MinInternalToCrossRatio, c.MaxCrossRelations
+	for _, o := range c.Overrides {
+		if !containsFold(o.Names, b.Name) && !hasTag(b.Tags, toStringSet(o.Tags)) {
+			continue
+		}
+		if o.MinInternalToCrossRatio != nil {
+			minRatio = *o.MinInternalToCrossRatio
+		}
+		if o.MaxCrossRelations != nil {
+			maxCross = *o.MaxCrossRelations
+		}
+		break
+	}
+	if raw, ok := b.Meta[metaMinInternalToCrossRatio]; ok {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("meta %s must be a number, got %q", metaMinInternalToCrossRatio, raw)
+		}
+		minRatio = value
+	}
+	if raw, ok := b.Meta[metaMaxCrossRelations]; ok {
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, 0, fmt.Errorf("meta %s must be an integer, got %q", metaMaxCrossRelations, raw)
+		}
+		maxCross = value
+	}
+	return minRatio, maxCross, nil
+}
+
 type boundaryMetric struct {
 	name       string
 	path       string
+	location   *types.Location
+	boundary   *model.Boundary
 	containers map[string]struct{}
-	internal   int
-	cross      int
+	// internal and cross count outgoing relations of the member containers;
+	// inbound counts relations from outside targeting them.
+	internal int
+	cross    int
+	inbound  int
 }
 
-func collectBoundaryMetrics(m *model.Architecture, outgoing relationList) []boundaryMetric {
+func collectBoundaryMetrics(m *model.Architecture) []boundaryMetric {
+	outgoing := buildOutgoing(m)
+	incoming := buildIncoming(m)
 	metrics := make([]boundaryMetric, 0)
 	for idx := range m.Boundaries {
 		path := fmt.Sprintf("boundaries[%d]", idx)
-		metrics = append(metrics, computeMetrics(&m.Boundaries[idx], path, outgoing)...)
+		metrics = append(metrics, computeMetrics(&m.Boundaries[idx], path, outgoing, incoming)...)
 	}
 	return metrics
 }
 
-func computeMetrics(b *model.Boundary, path string, outgoing relationList) []boundaryMetric {
+func computeMetrics(b *model.Boundary, path string, outgoing, incoming relationList) []boundaryMetric {
 	current := boundaryMetric{
+		boundary:   b,
 		name:       b.Name,
 		path:       path,
+		location:   model.LocationPtr(b.Location),
 		containers: make(map[string]struct{}),
 	}
 	for _, c := range b.Containers {
@@ -108,7 +212,7 @@ func computeMetrics(b *model.Boundary, path string, outgoing relationList) []bou
 	metrics := []boundaryMetric{}
 	for idx := range b.Boundaries {
 		nestedPath := fmt.Sprintf("%s.boundaries[%d]", path, idx)
-		nestedMetrics := computeMetrics(&b.Boundaries[idx], nestedPath, outgoing)
+		nestedMetrics := computeMetrics(&b.Boundaries[idx], nestedPath, outgoing, incoming)
 		for _, nm := range nestedMetrics {
 			for name := range nm.containers {
 				current.containers[name] = struct{}{}
@@ -125,6 +229,11 @@ func computeMetrics(b *model.Boundary, path string, outgoing relationList) []bou
 				current.cross++
 			}
 		}
+		for _, rel := range incoming[name] {
+			if _, ok := current.containers[rel.Relation.From]; !ok {
+				current.inbound++
+			}
+		}
 	}
 
 	metrics = append([]boundaryMetric{current}, metrics...)
@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+const (
+	callDepthRuleID = "ARCH-CALL-DEPTH"
+
+	// Message classes, usable as severity override keys.
+	classCallDepthDepth = "depth"
+)
+
+type callDepthRule struct{}
+
+type callDepthConfig struct {
+	EntryTags  []string `json:"entryTags" doc:"Tags marking entry-point containers; empty treats every container as an entry point."`
+	MaxDepth   int      `json:"maxDepth" doc:"Maximum number of chained calls from an entry point; 0 disables the check."`
+	CountAsync bool     `json:"countAsync" doc:"Follow async relations too instead of letting them break the chain."`
+}
+
+var defaultCallDepthConfig = callDepthConfig{
+	EntryTags: []string{"edge", "acl"},
+	MaxDepth:  4,
+}
+
+// NewCallDepthRule limits the depth of synchronous call chains starting at
+// entry-point containers.
+func NewCallDepthRule() Rule { return &callDepthRule{} }
+
+func (r *callDepthRule) ID() string { return callDepthRuleID }
+
+func (r *callDepthRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              callDepthRuleID,
+		Description:     "Synchronous call chains from entry points stay within a maximum depth.",
+		DefaultSeverity: types.SeverityWarn,
+		Rationale:       "Every synchronous hop adds latency and another way to fail; deep chains turn one slow service into a cascading outage.",
+		DocURL:          types.RuleDocURL(callDepthRuleID),
+		Classes:         []string{classCallDepthDepth},
+		Config:          configSchema(defaultCallDepthConfig),
+	}
+}
+
+func (r *callDepthRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
+	conf := defaultCallDepthConfig
+	if err := decodeConfig(cfg, &conf); err != nil {
+		return []types.Finding{configFinding(callDepthRuleID, err)}
+	}
+	if conf.MaxDepth <= 0 {
+		return nil
+	}
+
+	follows := func(rel *model.Relation) bool {
+		return rel.Kind == model.RelationKindSync || (conf.CountAsync && rel.Kind == model.RelationKindAsync)
+	}
+	relations := m.Relations()
+	g := newDependencyGraph(relations, follows)
+	edges := map[[2]string]string{}
+	for _, relRef := range relations {
+		key := [2]string{relRef.Relation.From, relRef.Relation.To}
+		if _, seen := edges[key]; !seen && follows(relRef.Relation) {
+			edges[key] = relRef.Path
+		}
+	}
+
+	entryTags := toStringSet(conf.EntryTags)
+	findings := make([]types.Finding, 0)
+	for _, ref := range m.Containers() {
+		c := ref.Container
+		if len(entryTags) > 0 && !hasTag(c.Tags, entryTags) {
+			continue
+		}
+		if _, ok := g[c.Name]; !ok {
+			continue
+		}
+		chain := g.longestPath(c.Name)
+		depth := len(chain) - 1
+		if depth <= conf.MaxDepth {
+			continue
+		}
+		paths := make([]string, 0, depth)
+		for i := 1; i < len(chain); i++ {
+			paths = append(paths, edges[[2]string{chain[i-1], chain[i]}])
+		}
+		findings = append(findings, types.Finding{
+			RuleID:   callDepthRuleID,
+			Severity: types.SeverityWarn,
+			Message:  fmt.Sprintf("call chain from %s is %d calls deep (max %d): %s", c.Name, depth, conf.MaxDepth, strings.Join(chain, " -> ")),
+			Class:    classCallDepthDepth,
+			Path:     ref.Path,
+			Location: ref.Location(),
+			Meta: map[string]any{
+				"chain":     chain,
+				"relations": paths,
+				"depth":     depth,
+				"maxDepth":  conf.MaxDepth,
+			},
+		})
+	}
+	return findings
+}
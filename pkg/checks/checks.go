@@ -8,5 +8,7 @@ import (
 // Rule describes a reusable architecture rule implementation.
 type Rule interface {
 	ID() string
+	// Metadata documents the rule for the CLI and reporters.
+	Metadata() types.RuleMetadata
 	Run(*model.Architecture, map[string]any) []types.Finding
 }
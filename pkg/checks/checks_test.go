@@ -1,12 +1,16 @@
 package checks_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/PET-dev-projects/ArchLint/pkg/checks"
 	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
 func TestAcyclicRule(t *testing.T) {
@@ -21,8 +25,38 @@ func TestAcyclicRule(t *testing.T) {
 	t.Run("cycle detected", func(t *testing.T) {
 		arch := loadArch(t, "arch_cycle.yaml")
 		findings := checks.NewAcyclicRule().Run(arch, nil)
-		if len(findings) == 0 {
-			t.Fatal("expected cycle finding")
+		if len(findings) != 1 || findings[0].Path != "boundaries[0].relations[0]" {
+			t.Fatalf("expected one cycle finding, got %v", findings)
+		}
+		if cycle, _ := findings[0].Meta["cycle"].([]string); strings.Join(cycle, ">") != "api>repo>api" {
+			t.Fatalf("expected canonical cycle, got %v", findings[0].Meta)
+		}
+	})
+
+	t.Run("strongly connected components", func(t *testing.T) {
+		arch := loadArch(t, "arch_cycle_tangle.yaml")
+		first := checks.NewAcyclicRule().Run(arch, nil)
+		if len(first) != 2 {
+			t.Fatalf("expected one finding per component, got %v", first)
+		}
+		members, _ := first[0].Meta["members"].([]string)
+		relations, _ := first[0].Meta["relations"].([]string)
+		if strings.Join(members, ",") != "accounts-api,accounts-core,billing-api" || len(relations) != 4 {
+			t.Fatalf("unexpected component meta: %v", first[0].Meta)
+		}
+		if cycle, _ := first[0].Meta["cycle"].([]string); strings.Join(cycle, ">") != "accounts-api>accounts-core>accounts-api" {
+			t.Fatalf("expected shortest cycle from the first member, got %v", cycle)
+		}
+		for i := 0; i < 10; i++ {
+			again := checks.NewAcyclicRule().Run(arch, nil)
+			if !reflect.DeepEqual(first, again) {
+				t.Fatalf("cycle findings differ between runs:\n%v\n%v", first, again)
+			}
+		}
+
+		crossing := checks.NewAcyclicRule().Run(arch, map[string]any{"crossBoundaryOnly": true})
+		if len(crossing) != 1 || crossing[0].Path != "boundaries[0].relations[0]" {
+			t.Fatalf("expected only the cross-boundary component, got %v", crossing)
 		}
 	})
 }
@@ -41,6 +75,246 @@ func TestACLRule(t *testing.T) {
 	if len(findings) == 0 {
 		t.Fatalf("expected acl finding, got %v", findings)
 	}
+	if loc := findings[0].Location; loc == nil || loc.Line != 19 || loc.Column != 9 {
+		t.Fatalf("expected finding location at 19:9, got %+v", loc)
+	}
+}
+
+func TestRuleConfigKeepsDefaults(t *testing.T) {
+	arch := &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{{
+			Name:       "Core",
+			Containers: []model.Container{{Name: "api", Type: model.ContainerService, Tags: []string{"acl"}}},
+			Relations:  []model.Relation{{From: "api", To: "audit", Kind: model.RelationKindSync}},
+		}},
+		Externals: []model.Container{{Name: "audit", Type: model.ContainerExternal}},
+	}
+	rule := checks.NewACLRule()
+
+	if findings := rule.Run(arch, map[string]any{"allowedTags": []any{"gateway"}}); len(findings) != 1 {
+		t.Fatalf("expected acl finding once only gateway is allowed, got %v", findings)
+	}
+	if findings := rule.Run(arch, nil); len(findings) != 0 {
+		t.Fatalf("a list override must not change the defaults of later runs, got %v", findings)
+	}
+	if got := rule.Metadata().Config[0].Default; !reflect.DeepEqual(got, []string{"acl"}) {
+		t.Fatalf("expected default allowedTags [acl], got %v", got)
+	}
+}
+
+func TestLayersRule(t *testing.T) {
+	arch := loadArch(t, "arch_layers.yaml")
+	cfg := map[string]any{
+		"layers": []any{
+			map[string]any{"name": "edge", "tags": []any{"edge"}},
+			map[string]any{"name": "application", "tags": []any{"application"}},
+			map[string]any{"name": "domain", "tags": []any{"domain"}},
+			map[string]any{"name": "data", "types": []any{"database"}},
+		},
+	}
+
+	findings := checks.NewLayersRule().Run(arch, cfg)
+	if len(findings) != 1 || findings[0].Path != "boundaries[0].relations[2]" || findings[0].Class != "upward" {
+		t.Fatalf("expected upward pricing -> orders finding, got %v", findings)
+	}
+	if findings[0].Meta["fromLayer"] != "domain" || findings[0].Meta["toLayer"] != "application" {
+		t.Fatalf("unexpected layer meta: %v", findings[0].Meta)
+	}
+
+	cfg["strict"] = true
+	findings = checks.NewLayersRule().Run(arch, cfg)
+	if len(findings) != 2 || findings[1].Path != "boundaries[0].relations[3]" || findings[1].Class != "skip" {
+		t.Fatalf("expected web -> orders-db to skip layers in strict mode, got %v", findings)
+	}
+
+	if findings := checks.NewLayersRule().Run(arch, nil); len(findings) != 0 {
+		t.Fatalf("rule without layers must be a no-op, got %v", findings)
+	}
+	bad := map[string]any{"layers": []any{map[string]any{"name": "edge"}}}
+	if findings := checks.NewLayersRule().Run(arch, bad); len(findings) != 1 || findings[0].Class != checks.ClassConfig {
+		t.Fatalf("expected configuration finding, got %v", findings)
+	}
+}
+
+func TestCouplingRule(t *testing.T) {
+	arch := loadArch(t, "arch_layers.yaml")
+
+	if findings := checks.NewCouplingRule().Run(arch, nil); len(findings) != 0 {
+		t.Fatalf("default config must not report anything, got %v", findings)
+	}
+
+	cfg := map[string]any{
+		"maxFanOut": 1,
+		"overrides": []any{
+			map[string]any{"tags": []any{"edge"}, "maxFanOut": 2},
+		},
+		"stableDependencies": true,
+	}
+	findings := checks.NewCouplingRule().Run(arch, cfg)
+	if len(findings) != 2 {
+		t.Fatalf("expected fan-out and stable-dependencies findings, got %v", findings)
+	}
+	if findings[0].Class != "fan-out" || findings[0].Path != "boundaries[0].containers[2]" || findings[0].Meta["fanOut"] != 2 {
+		t.Fatalf("expected pricing fan-out finding, got %v", findings[0])
+	}
+	if findings[1].Class != "stable-dependencies" || findings[1].Path != "boundaries[0].relations[1]" {
+		t.Fatalf("expected orders -> pricing to violate stable dependencies, got %v", findings[1])
+	}
+
+	cfg["tolerance"] = 0.5
+	if findings := checks.NewCouplingRule().Run(arch, cfg); len(findings) != 1 {
+		t.Fatalf("tolerance must absorb the instability gap, got %v", findings)
+	}
+
+	off := map[string]any{
+		"maxFanOut": 1,
+		"overrides": []any{
+			map[string]any{"tags": []any{"domain", "edge"}, "maxFanOut": 0},
+		},
+	}
+	if findings := checks.NewCouplingRule().Run(arch, off); len(findings) != 0 {
+		t.Fatalf("maxFanOut 0 in an override must switch the global limit off, got %v", findings)
+	}
+
+	shared := loadArch(t, "arch_shared_db.yaml")
+	findings = checks.NewCouplingRule().Run(shared, map[string]any{"boundaryMaxInstability": 0.5})
+	if len(findings) != 1 || findings[0].Class != "instability" || findings[0].Path != "boundaries[1]" || findings[0].Meta["instability"] != 1.0 {
+		t.Fatalf("expected Billing boundary instability finding, got %v", findings)
+	}
+
+	many := &model.Architecture{Version: 1, Externals: []model.Container{{Name: "ext", Type: model.ContainerExternal}}}
+	for i := 0; i < 11; i++ {
+		name := fmt.Sprintf("svc%d", i)
+		many.Boundaries = append(many.Boundaries, model.Boundary{
+			Name:       fmt.Sprintf("B%d", i),
+			Containers: []model.Container{{Name: name, Type: model.ContainerService}},
+			Relations:  []model.Relation{{From: name, To: "ext", Kind: model.RelationKindSync}},
+		})
+	}
+	findings = checks.NewCouplingRule().Run(many, map[string]any{"boundaryMaxInstability": 0.5})
+	if len(findings) != 11 || findings[2].Path != "boundaries[2]" || findings[10].Path != "boundaries[10]" {
+		t.Fatalf("expected boundary findings in declaration order, got %v", findings)
+	}
+}
+
+func TestCallDepthRule(t *testing.T) {
+	arch := loadArch(t, "arch_call_depth.yaml")
+
+	if findings := checks.NewCallDepthRule().Run(arch, nil); len(findings) != 0 {
+		t.Fatalf("chain of 3 calls is within the default depth, got %v", findings)
+	}
+
+	findings := checks.NewCallDepthRule().Run(arch, map[string]any{"maxDepth": 2})
+	if len(findings) != 1 || findings[0].Path != "boundaries[0].containers[0]" || findings[0].Meta["depth"] != 3 {
+		t.Fatalf("expected gateway chain finding, got %v", findings)
+	}
+	relations, _ := findings[0].Meta["relations"].([]string)
+	if len(relations) != 3 || relations[2] != "boundaries[0].relations[2]" {
+		t.Fatalf("expected chain relation paths, got %v", findings[0].Meta)
+	}
+
+	findings = checks.NewCallDepthRule().Run(arch, map[string]any{"maxDepth": 4, "countAsync": true})
+	if len(findings) != 1 || findings[0].Meta["depth"] != 5 {
+		t.Fatalf("async relations must extend the chain when counted, got %v", findings)
+	}
+}
+
+func TestCallDepthRuleThroughCycle(t *testing.T) {
+	var containers []model.Container
+	for _, name := range []string{"a", "b", "c", "x", "y", "z"} {
+		containers = append(containers, model.Container{Name: name, Type: model.ContainerService})
+	}
+	containers[0].Tags = []string{"edge"}
+	var relations []model.Relation
+	for _, edge := range []string{"a>b", "a>c", "b>c", "c>b", "b>x", "x>y", "y>z"} {
+		from, to, _ := strings.Cut(edge, ">")
+		relations = append(relations, model.Relation{From: from, To: to, Kind: model.RelationKindSync})
+	}
+	arch := &model.Architecture{
+		Version:    1,
+		Boundaries: []model.Boundary{{Name: "Graph", Containers: containers, Relations: relations}},
+	}
+
+	findings := checks.NewCallDepthRule().Run(arch, nil)
+	if len(findings) != 1 || findings[0].Meta["depth"] != 5 {
+		t.Fatalf("expected a -> c -> b -> x -> y -> z to exceed the default depth, got %v", findings)
+	}
+	chain, _ := findings[0].Meta["chain"].([]string)
+	if strings.Join(chain, ">") != "a>c>b>x>y>z" {
+		t.Fatalf("unexpected chain %v", chain)
+	}
+}
+
+func TestSharedDatabaseRule(t *testing.T) {
+	arch := loadArch(t, "arch_shared_db.yaml")
+
+	findings := checks.NewSharedDatabaseRule().Run(arch, nil)
+	if len(findings) != 1 || findings[0].Path != "boundaries[0].containers[2]" || findings[0].Class != "multi-boundary" {
+		t.Fatalf("expected orders-db shared across boundaries, got %v", findings)
+	}
+	accessors, _ := findings[0].Meta["accessors"].([]map[string]string)
+	if len(accessors) != 3 || accessors[0]["container"] != "billing-svc" || accessors[0]["boundary"] != "Billing" {
+		t.Fatalf("expected sorted accessors with boundaries, got %v", findings[0].Meta)
+	}
+
+	findings = checks.NewSharedDatabaseRule().Run(arch, map[string]any{"maxBoundaries": 0, "maxAccessors": 1, "exemptTags": []any{}})
+	if len(findings) != 2 || findings[0].Class != "accessors" || findings[1].Path != "boundaries[0].containers[3]" {
+		t.Fatalf("expected accessor findings for both databases, got %v", findings)
+	}
+
+	nested := &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{{
+			Name: "Orders",
+			Containers: []model.Container{
+				{Name: "orders-svc", Type: model.ContainerService},
+				{Name: "orders-db", Type: model.ContainerDatabase},
+			},
+			Boundaries: []model.Boundary{{
+				Name:       "Reporting",
+				Containers: []model.Container{{Name: "report-svc", Type: model.ContainerService}},
+				Relations:  []model.Relation{{From: "report-svc", To: "orders-db", Kind: model.RelationKindDB}},
+			}},
+			Relations: []model.Relation{{From: "orders-svc", To: "orders-db", Kind: model.RelationKindDB}},
+		}},
+	}
+	if findings := checks.NewSharedDatabaseRule().Run(nested, nil); len(findings) != 0 {
+		t.Fatalf("a nested boundary must count as its top-level boundary, got %v", findings)
+	}
+	findings = checks.NewSharedDatabaseRule().Run(nested, map[string]any{"nestedBoundaries": true})
+	if len(findings) != 1 || !reflect.DeepEqual(findings[0].Meta["boundaries"], []string{"Orders", "Reporting"}) {
+		t.Fatalf("expected Orders and Reporting to share orders-db when nesting counts, got %v", findings)
+	}
+}
+
+func TestUnusedInterfaceRule(t *testing.T) {
+	arch, err := model.LoadModelFromFile(filepath.Join("..", "..", "testdata", "arch_interfaces.yaml"))
+	if err != nil {
+		t.Fatalf("load yaml: %v", err)
+	}
+
+	findings := checks.NewUnusedInterfaceRule().Run(arch, nil)
+	if len(findings) != 2 || findings[0].Path != "boundaries[0].containers[1].provides[2]" || findings[1].Meta["interface"] != "orderCancelled" {
+		t.Fatalf("expected grpc cancel and orderCancelled to be unused, got %v", findings)
+	}
+	if findings[0].Location == nil || findings[0].Location.Line != 14 || findings[1].Location == nil || findings[1].Location.Line != 19 {
+		t.Fatalf("expected findings at the provides entries, got %v and %v", findings[0].Location, findings[1].Location)
+	}
+
+	findings = checks.NewUnusedInterfaceRule().Run(arch, map[string]any{"exemptTags": []any{"broker"}})
+	if len(findings) != 2 || findings[0].Meta["container"] != "web" {
+		t.Fatalf("expected web interface once edge is no longer exempt, got %v", findings)
+	}
+
+	arch.Boundaries[0].Relations[0].Uses = ""
+	if findings := checks.NewUnusedInterfaceRule().Run(arch, nil); len(findings) != 1 {
+		t.Fatalf("relations without uses must cover every interface of their target, got %v", findings)
+	}
+	findings = checks.NewUnusedInterfaceRule().Run(arch, map[string]any{"requireUses": true})
+	if len(findings) != 3 || findings[0].Meta["interface"] != "createOrder" {
+		t.Fatalf("expected createOrder unused with requireUses, got %v", findings)
+	}
 }
 
 func TestBoundariesRule(t *testing.T) {
@@ -51,6 +325,41 @@ func TestBoundariesRule(t *testing.T) {
 	}
 }
 
+func TestBoundariesRuleOverrides(t *testing.T) {
+	arch := loadArch(t, "arch_boundary_overrides.yaml")
+
+	findings := checks.NewBoundariesRule().Run(arch, nil)
+	if len(findings) != 1 || findings[0].Path != "boundaries[0]" {
+		t.Fatalf("expected only the gateway ratio finding, got %v", findings)
+	}
+
+	cfg := map[string]any{
+		"overrides": []any{
+			map[string]any{"tags": []any{"gateway"}, "minInternalToCrossRatio": 0},
+		},
+	}
+	if findings := checks.NewBoundariesRule().Run(arch, cfg); len(findings) != 0 {
+		t.Fatalf("gateway override must silence the ratio finding, got %v", findings)
+	}
+
+	cfg["direction"] = "both"
+	findings = checks.NewBoundariesRule().Run(arch, cfg)
+	if len(findings) != 1 || findings[0].Path != "boundaries[1]" || findings[0].Class != "cross-relations" || findings[0].Meta["cross"] != 2 {
+		t.Fatalf("expected inbound relations to exceed the Core meta limit, got %v", findings)
+	}
+
+	cfg["direction"] = "sideways"
+	if findings := checks.NewBoundariesRule().Run(arch, cfg); len(findings) != 1 || findings[0].Class != checks.ClassConfig {
+		t.Fatalf("expected configuration finding for direction, got %v", findings)
+	}
+
+	arch.Boundaries[1].Meta["archlint.maxCrossRelations"] = "many"
+	findings = checks.NewBoundariesRule().Run(arch, nil)
+	if len(findings) != 2 || findings[1].Path != "boundaries[1].meta" || findings[1].Class != checks.ClassConfig {
+		t.Fatalf("expected configuration finding for invalid meta, got %v", findings)
+	}
+}
+
 func TestExternalProtocolRule(t *testing.T) {
 	arch := loadArch(t, "arch_external_protocol.yaml")
 	findings := checks.NewExternalProtocolRule().Run(arch, nil)
@@ -67,6 +376,122 @@ func TestDatabaseIsolationRule(t *testing.T) {
 	}
 }
 
+func TestRuleMetadata(t *testing.T) {
+	for _, meta := range checks.DefaultRegistry().Metadata() {
+		if meta.Description == "" || meta.Rationale == "" || meta.DocURL == "" || len(meta.Classes) == 0 {
+			t.Fatalf("incomplete metadata for %s: %+v", meta.ID, meta)
+		}
+		if len(meta.Config) == 0 {
+			t.Fatalf("%s: expected config schema", meta.ID)
+		}
+		for _, field := range meta.Config {
+			if field.Name == "" || field.Type == "" || field.Description == "" {
+				t.Fatalf("%s: incomplete config field %+v", meta.ID, field)
+			}
+		}
+	}
+
+	fixtures, err := filepath.Glob(filepath.Join("..", "..", "testdata", "arch_*.yaml"))
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("glob fixtures: %v", err)
+	}
+	for _, fixture := range fixtures {
+		arch, err := model.LoadModelFromFile(fixture)
+		if err != nil {
+			t.Fatalf("load %s: %v", fixture, err)
+		}
+		for _, rule := range checks.DefaultRegistry().Rules() {
+			meta := rule.Metadata()
+			for _, f := range rule.Run(arch, nil) {
+				want, ok := meta.ClassSeverities[f.Class]
+				if !ok {
+					want = meta.DefaultSeverity
+				}
+				if f.Class != checks.ClassConfig && f.Severity != want {
+					t.Fatalf("%s: %s finding of class %s has severity %s, metadata says %s", filepath.Base(fixture), meta.ID, f.Class, f.Severity, want)
+				}
+			}
+		}
+	}
+
+	rule, _ := checks.DefaultRegistry().Find("ARCH-CRUD")
+	fields := rule.Metadata().Config
+	if len(fields) != 2 || fields[0].Name != "allowedTags" || fields[0].Type != "list of string" {
+		t.Fatalf("unexpected ARCH-CRUD schema: %+v", fields)
+	}
+	if tags, ok := fields[0].Default.([]string); !ok || len(tags) != 3 {
+		t.Fatalf("expected allowedTags default from rule config, got %#v", fields[0].Default)
+	}
+}
+
+func TestDeclarativeRule(t *testing.T) {
+	arch := loadArch(t, "arch_valid.yaml")
+
+	t.Run("relation selector", func(t *testing.T) {
+		rule := checks.NewDeclarativeRule(checks.RuleDefinition{
+			ID:       "TEAM-NO-DB",
+			Severity: types.SeverityWarn,
+			Message:  "{{.From}} must not reach database {{.To}} ({{.Kind}})",
+			Match: checks.Match{Relation: &checks.RelationSelector{
+				From: &checks.ContainerSelector{Tags: []string{"repo"}},
+				To:   &checks.ContainerSelector{Types: []model.ContainerType{model.ContainerDatabase}},
+			}},
+		})
+		findings := rule.Run(arch, nil)
+		if len(findings) != 1 {
+			t.Fatalf("expected one finding, got %v", findings)
+		}
+		f := findings[0]
+		if f.Severity != types.SeverityWarn || f.Message != "repo must not reach database db (db)" {
+			t.Fatalf("unexpected finding: %+v", f)
+		}
+		if f.Path != "boundaries[0].relations[1]" {
+			t.Fatalf("unexpected path %q", f.Path)
+		}
+	})
+
+	t.Run("cross boundary", func(t *testing.T) {
+		crossing := true
+		rule := checks.NewDeclarativeRule(checks.RuleDefinition{
+			ID:       "TEAM-CROSS",
+			Severity: types.SeverityError,
+			Match: checks.Match{Relation: &checks.RelationSelector{
+				Kinds:         []model.RelationKind{model.RelationKindSync},
+				CrossBoundary: &crossing,
+				From:          &checks.ContainerSelector{Boundaries: []string{"core services"}},
+			}},
+		})
+		findings := rule.Run(arch, nil)
+		if len(findings) != 1 || findings[0].Meta["to"] != "audit" {
+			t.Fatalf("expected api -> audit finding, got %v", findings)
+		}
+	})
+
+	t.Run("container selector", func(t *testing.T) {
+		rule := checks.NewDeclarativeRule(checks.RuleDefinition{
+			ID:       "TEAM-ACL",
+			Severity: types.SeverityInfo,
+			Message:  "{{.Container}} in {{.Boundary}}",
+			Match:    checks.Match{Container: &checks.ContainerSelector{Tags: []string{"acl"}}},
+		})
+		findings := rule.Run(arch, nil)
+		if len(findings) != 1 || findings[0].Message != "api in Core Services" {
+			t.Fatalf("unexpected findings %v", findings)
+		}
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		def := checks.RuleDefinition{ID: "TEAM-BAD", Severity: types.SeverityError}
+		if err := checks.ValidateDefinition(def); err == nil {
+			t.Fatal("expected validation error for missing match")
+		}
+		findings := checks.NewDeclarativeRule(def).Run(arch, nil)
+		if len(findings) != 1 || findings[0].Path != "options.ruleConfig[TEAM-BAD]" {
+			t.Fatalf("expected configuration finding, got %v", findings)
+		}
+	})
+}
+
 func loadArch(t *testing.T, name string) *model.Architecture {
 	t.Helper()
 	path := filepath.Join("..", "..", "testdata", name)
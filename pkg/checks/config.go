@@ -1,6 +1,13 @@
 package checks
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
 
 func decodeConfig(cfg map[string]any, target any) error {
 	if cfg == nil {
@@ -10,5 +17,111 @@ func decodeConfig(cfg map[string]any, target any) error {
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(data, target)
+	detachDefaults(target)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(target)
+}
+
+// detachDefaults gives the slice and map fields of the struct target points
+// to their own storage. Rules start from a copy of their package-level
+// default config, and encoding/json decodes into existing slices and maps in
+// place, which would otherwise overwrite the defaults for later runs.
+func detachDefaults(target any) {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Slice:
+			if field.IsNil() {
+				continue
+			}
+			detached := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+			reflect.Copy(detached, field)
+			field.Set(detached)
+		case reflect.Map:
+			if field.IsNil() {
+				continue
+			}
+			detached := reflect.MakeMapWithSize(field.Type(), field.Len())
+			iter := field.MapRange()
+			for iter.Next() {
+				detached.SetMapIndex(iter.Key(), iter.Value())
+			}
+			field.Set(detached)
+		}
+	}
+}
+
+// configSchema describes the fields of a rule config struct from its json
+// and doc tags, taking defaults from the given value.
+func configSchema(defaults any) []types.ConfigField {
+	v := reflect.ValueOf(defaults)
+	return schemaFields(v.Type(), &v)
+}
+
+// schemaFields describes the json-tagged fields of struct type t. Defaults
+// are read from v when it is not nil.
+func schemaFields(t reflect.Type, v *reflect.Value) []types.ConfigField {
+	fields := make([]types.ConfigField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		entry := types.ConfigField{
+			Name:        name,
+			Type:        schemaType(field.Type),
+			Description: field.Tag.Get("doc"),
+			Fields:      nestedFields(field.Type),
+		}
+		if v != nil {
+			if value := v.Field(i); !value.IsZero() {
+				entry.Default = value.Interface()
+			}
+		}
+		fields = append(fields, entry)
+	}
+	return fields
+}
+
+// nestedFields describes the object held by t, directly or as the element of
+// pointers, lists and maps, or returns nil when t holds no object.
+func nestedFields(t reflect.Type) []types.ConfigField {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return schemaFields(t, nil)
+}
+
+func schemaType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaType(t.Elem())
+	case reflect.Slice:
+		return "list of " + schemaType(t.Elem())
+	case reflect.Map:
+		return "map of " + schemaType(t.Elem())
+	case reflect.Struct:
+		return "object"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
 }
@@ -0,0 +1,272 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+const (
+	couplingRuleID = "ARCH-COUPLING"
+
+	// Message classes, usable as severity override keys.
+	classCouplingFanIn       = "fan-in"
+	classCouplingFanOut      = "fan-out"
+	classCouplingInstability = "instability"
+	classCouplingStable      = "stable-dependencies"
+)
+
+type couplingRule struct{}
+
+type couplingOverride struct {
+	Tags           []string `json:"tags"`
+	MaxFanIn       *int     `json:"maxFanIn"`
+	MaxFanOut      *int     `json:"maxFanOut"`
+	MaxInstability *float64 `json:"maxInstability"`
+}
+
+type couplingConfig struct {
+	MaxFanIn               int                `json:"maxFanIn" doc:"Maximum distinct containers depending on a container; 0 disables the check."`
+	MaxFanOut              int                `json:"maxFanOut" doc:"Maximum distinct containers a container depends on; 0 disables the check."`
+	MaxInstability         *float64           `json:"maxInstability" doc:"Maximum instability Ce/(Ca+Ce) of a container; unset disables the check."`
+	BoundaryMaxFanIn       int                `json:"boundaryMaxFanIn" doc:"Maximum outside containers depending on a boundary; 0 disables the check."`
+	BoundaryMaxFanOut      int                `json:"boundaryMaxFanOut" doc:"Maximum outside containers a boundary depends on; 0 disables the check."`
+	BoundaryMaxInstability *float64           `json:"boundaryMaxInstability" doc:"Maximum instability of a boundary from its outside fan-in and fan-out; unset disables the check."`
+	StableDependencies     bool               `json:"stableDependencies" doc:"Report relations to a container less stable than the caller."`
+	Tolerance              float64            `json:"tolerance" doc:"Instability difference allowed before a stable-dependencies finding."`
+	Overrides              []couplingOverride `json:"overrides" doc:"Container limits by tag: {tags, maxFanIn, maxFanOut, maxInstability}; the first match wins, unset limits inherit and 0 switches a limit off."`
+}
+
+var defaultCouplingConfig = couplingConfig{}
+
+// couplingMetric holds Martin's coupling numbers for a container or boundary.
+type couplingMetric struct {
+	fanIn       int
+	fanOut      int
+	instability float64
+}
+
+func (c couplingMetric) meta() map[string]any {
+	return map[string]any{
+		"fanIn":       c.fanIn,
+		"fanOut":      c.fanOut,
+		"instability": c.instability,
+	}
+}
+
+func newCouplingMetric(fanIn, fanOut int) couplingMetric {
+	metric := couplingMetric{fanIn: fanIn, fanOut: fanOut}
+	if fanIn+fanOut > 0 {
+		metric.instability = float64(fanOut) / float64(fanIn+fanOut)
+	}
+	return metric
+}
+
+// NewCouplingRule reports fan-in, fan-out and instability outliers and
+// violations of the stable dependencies principle.
+func NewCouplingRule() Rule { return &couplingRule{} }
+
+func (r *couplingRule) ID() string { return couplingRuleID }
+
+func (r *couplingRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              couplingRuleID,
+		Description:     "Containers and boundaries stay within coupling limits and depend on more stable containers.",
+		DefaultSeverity: types.SeverityWarn,
+		Rationale:       "Highly coupled containers are expensive to change, and depending on something less stable than yourself imports its churn.",
+		DocURL:          types.RuleDocURL(couplingRuleID),
+		Classes:         []string{classCouplingFanIn, classCouplingFanOut, classCouplingInstability, classCouplingStable},
+		Config:          configSchema(defaultCouplingConfig),
+	}
+}
+
+func (r *couplingRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
+	conf := defaultCouplingConfig
+	if err := decodeConfig(cfg, &conf); err != nil {
+		return []types.Finding{configFinding(couplingRuleID, err)}
+	}
+
+	metrics := containerCoupling(m)
+	findings := make([]types.Finding, 0)
+	for _, ref := range m.Containers() {
+		metric, ok := metrics[ref.Container.Name]
+		if !ok {
+			continue
+		}
+		maxFanIn, maxFanOut, maxInstability := conf.limitsFor(ref.Container)
+		report := func(class, message string, limit any) {
+			meta := metric.meta()
+			meta["limit"] = limit
+			findings = append(findings, types.Finding{
+				RuleID:   couplingRuleID,
+				Severity: types.SeverityWarn,
+				Message:  message,
+				Class:    class,
+				Path:     ref.Path,
+				Location: ref.Location(),
+				Meta:     meta,
+			})
+		}
+		name := ref.Container.Name
+		if maxFanIn > 0 && metric.fanIn > maxFanIn {
+			report(classCouplingFanIn, fmt.Sprintf("container %s has fan-in %d (max %d)", name, metric.fanIn, maxFanIn), maxFanIn)
+		}
+		if maxFanOut > 0 && metric.fanOut > maxFanOut {
+			report(classCouplingFanOut, fmt.Sprintf("container %s has fan-out %d (max %d)", name, metric.fanOut, maxFanOut), maxFanOut)
+		}
+		if maxInstability != nil && metric.instability > *maxInstability {
+			report(classCouplingInstability, fmt.Sprintf("container %s has instability %.2f (max %.2f)", name, metric.instability, *maxInstability), *maxInstability)
+		}
+	}
+
+	if conf.BoundaryMaxFanIn > 0 || conf.BoundaryMaxFanOut > 0 || conf.BoundaryMaxInstability != nil {
+		for _, b := range boundaryCoupling(m) {
+			report := func(class, message string, limit any) {
+				meta := b.metric.meta()
+				meta["limit"] = limit
+				findings = append(findings, types.Finding{
+					RuleID:   couplingRuleID,
+					Severity: types.SeverityWarn,
+					Message:  message,
+					Class:    class,
+					Path:     b.path,
+					Location: b.location,
+					Meta:     meta,
+				})
+			}
+			if conf.BoundaryMaxFanIn > 0 && b.metric.fanIn > conf.BoundaryMaxFanIn {
+				report(classCouplingFanIn, fmt.Sprintf("boundary %s has fan-in %d (max %d)", b.name, b.metric.fanIn, conf.BoundaryMaxFanIn), conf.BoundaryMaxFanIn)
+			}
+			if conf.BoundaryMaxFanOut > 0 && b.metric.fanOut > conf.BoundaryMaxFanOut {
+				report(classCouplingFanOut, fmt.Sprintf("boundary %s has fan-out %d (max %d)", b.name, b.metric.fanOut, conf.BoundaryMaxFanOut), conf.BoundaryMaxFanOut)
+			}
+			isolated := b.metric.fanIn+b.metric.fanOut == 0
+			if limit := conf.BoundaryMaxInstability; limit != nil && !isolated && b.metric.instability > *limit {
+				report(classCouplingInstability, fmt.Sprintf("boundary %s has instability %.2f (max %.2f)", b.name, b.metric.instability, *limit), *limit)
+			}
+		}
+	}
+
+	if conf.StableDependencies {
+		for _, relRef := range m.Relations() {
+			rel := relRef.Relation
+			from, okFrom := metrics[rel.From]
+			to, okTo := metrics[rel.To]
+			if !okFrom || !okTo || rel.From == rel.To || to.instability <= from.instability+conf.Tolerance {
+				continue
+			}
+			findings = append(findings, types.Finding{
+				RuleID:   couplingRuleID,
+				Severity: types.SeverityWarn,
+				Message: fmt.Sprintf("%s (instability %.2f) depends on less stable %s (instability %.2f)",
+					rel.From, from.instability, rel.To, to.instability),
+				Class:    classCouplingStable,
+				Path:     relRef.Path,
+				Location: relRef.Location(),
+				Meta: map[string]any{
+					"fromInstability": from.instability,
+					"toInstability":   to.instability,
+				},
+			})
+		}
+	}
+
+	return findings
+}
+
+// limitsFor returns the fan-in, fan-out and instability limits of container:
+// the global ones, replaced by those set in the first matching override.
+func (c couplingConfig) limitsFor(container *model.Container) (maxFanIn, maxFanOut int, maxInstability *float64) {
+	maxFanIn, maxFanOut, maxInstability = c.MaxFanIn, c.MaxFanOut, c.MaxInstability
+	for _, o := range c.Overrides {
+		if !hasTag(container.Tags, toStringSet(o.Tags)) {
+			continue
+		}
+		if o.MaxFanIn != nil {
+			maxFanIn = *o.MaxFanIn
+		}
+		if o.MaxFanOut != nil {
+			maxFanOut = *o.MaxFanOut
+		}
+		if o.MaxInstability != nil {
+			maxInstability = o.MaxInstability
+		}
+		break
+	}
+	return maxFanIn, maxFanOut, maxInstability
+}
+
+// containerCoupling computes fan-in (Ca) and fan-out (Ce) over distinct
+// neighbours for every container that takes part in a relation.
+func containerCoupling(m *model.Architecture) map[string]couplingMetric {
+	containers := m.ContainerMap()
+	in := map[string]map[string]struct{}{}
+	out := map[string]map[string]struct{}{}
+	for _, relRef := range m.Relations() {
+		rel := relRef.Relation
+		if rel.From == rel.To {
+			continue
+		}
+		if _, ok := containers[rel.From]; !ok {
+			continue
+		}
+		if _, ok := containers[rel.To]; !ok {
+			continue
+		}
+		addNeighbour(out, rel.From, rel.To)
+		addNeighbour(in, rel.To, rel.From)
+	}
+
+	metrics := map[string]couplingMetric{}
+	for name := range containers {
+		if len(in[name])+len(out[name]) == 0 {
+			continue
+		}
+		metrics[name] = newCouplingMetric(len(in[name]), len(out[name]))
+	}
+	return metrics
+}
+
+type boundaryCouplingMetric struct {
+	name     string
+	path     string
+	location *types.Location
+	metric   couplingMetric
+}
+
+// boundaryCoupling counts distinct outside containers depending on (fan-in)
+// and depended on by (fan-out) each boundary, nested boundaries included.
+func boundaryCoupling(m *model.Architecture) []boundaryCouplingMetric {
+	relations := m.Relations()
+	result := make([]boundaryCouplingMetric, 0)
+	for _, b := range collectBoundaryMetrics(m) {
+		in := map[string]struct{}{}
+		out := map[string]struct{}{}
+		for _, relRef := range relations {
+			rel := relRef.Relation
+			_, fromInside := b.containers[rel.From]
+			_, toInside := b.containers[rel.To]
+			switch {
+			case fromInside && !toInside:
+				out[rel.To] = struct{}{}
+			case toInside && !fromInside:
+				in[rel.From] = struct{}{}
+			}
+		}
+		result = append(result, boundaryCouplingMetric{
+			name:     b.name,
+			path:     b.path,
+			location: b.location,
+			metric:   newCouplingMetric(len(in), len(out)),
+		})
+	}
+	return result
+}
+
+func addNeighbour(index map[string]map[string]struct{}, name, neighbour string) {
+	if index[name] == nil {
+		index[name] = map[string]struct{}{}
+	}
+	index[name][neighbour] = struct{}{}
+}
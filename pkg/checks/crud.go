@@ -7,13 +7,20 @@ import (
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-const crudRuleID = "ARCH-CRUD"
+const (
+	crudRuleID = "ARCH-CRUD"
+
+	// Message classes, usable as severity override keys.
+	classCRUDKind      = "db-kind"
+	classCRUDAccess    = "db-access"
+	classCRUDExclusive = "exclusive"
+)
 
 type crudRule struct{}
 
 type crudConfig struct {
-	AllowedTags   []string `json:"allowedTags"`
-	ExclusiveTags []string `json:"exclusiveTags"`
+	AllowedTags   []string `json:"allowedTags" doc:"Tags that allow a container to access databases."`
+	ExclusiveTags []string `json:"exclusiveTags" doc:"Tags that restrict a container to database relations only."`
 }
 
 var defaultCrudConfig = crudConfig{
@@ -26,6 +33,18 @@ func NewCRUDRule() Rule { return &crudRule{} }
 
 func (r *crudRule) ID() string { return crudRuleID }
 
+func (r *crudRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              crudRuleID,
+		Description:     "Databases are accessed only via db relations from CRUD-tagged containers.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "Routing data access through dedicated repository services keeps schemas private and gives every database a single owner.",
+		DocURL:          types.RuleDocURL(crudRuleID),
+		Classes:         []string{classCRUDKind, classCRUDAccess, classCRUDExclusive},
+		Config:          configSchema(defaultCrudConfig),
+	}
+}
+
 func (r *crudRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
 	conf := defaultCrudConfig
 	if err := decodeConfig(cfg, &conf); err != nil {
@@ -53,7 +72,9 @@ func (r *crudRule) Run(m *model.Architecture, cfg map[string]any) []types.Findin
 					RuleID:   crudRuleID,
 					Severity: types.SeverityError,
 					Message:  fmt.Sprintf("relation to database %s must use kind 'db'", to.Container.Name),
+					Class:    classCRUDKind,
 					Path:     relRef.Path,
+					Location: relRef.Location(),
 				})
 			}
 			if !hasTag(from.Container.Tags, allowedTag) {
@@ -61,7 +82,9 @@ func (r *crudRule) Run(m *model.Architecture, cfg map[string]any) []types.Findin
 					RuleID:   crudRuleID,
 					Severity: types.SeverityError,
 					Message:  fmt.Sprintf("container %s must declare one of %v to access databases", from.Container.Name, conf.AllowedTags),
+					Class:    classCRUDAccess,
 					Path:     relRef.Path,
+					Location: relRef.Location(),
 				})
 			}
 		}
@@ -79,7 +102,9 @@ func (r *crudRule) Run(m *model.Architecture, cfg map[string]any) []types.Findin
 					RuleID:   crudRuleID,
 					Severity: types.SeverityError,
 					Message:  fmt.Sprintf("container %s is restricted to database relations", ref.Container.Name),
+					Class:    classCRUDExclusive,
 					Path:     relRef.Path,
+					Location: relRef.Location(),
 				})
 			}
 		}
@@ -7,12 +7,18 @@ import (
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-const databaseIsolationRuleID = "ARCH-DB-ISOLATION"
+const (
+	databaseIsolationRuleID = "ARCH-DB-ISOLATION"
+
+	// Message classes, usable as severity override keys.
+	classDBInitiates = "initiates-relation"
+	classDBNoInbound = "no-inbound"
+)
 
 type databaseIsolationRule struct{}
 
 type databaseIsolationConfig struct {
-	RequireInbound bool `json:"requireInbound"`
+	RequireInbound bool `json:"requireInbound" doc:"Report databases without inbound relations."`
 }
 
 var defaultDatabaseIsolationConfig = databaseIsolationConfig{
@@ -24,6 +30,19 @@ func NewDatabaseIsolationRule() Rule { return &databaseIsolationRule{} }
 
 func (r *databaseIsolationRule) ID() string { return databaseIsolationRuleID }
 
+func (r *databaseIsolationRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              databaseIsolationRuleID,
+		Description:     "Databases must not initiate relations and should have inbound access.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "Databases are passive stores: one that initiates calls hides behaviour outside the services, and one nobody uses is dead weight or a gap in the model.",
+		DocURL:          types.RuleDocURL(databaseIsolationRuleID),
+		Classes:         []string{classDBInitiates, classDBNoInbound},
+		ClassSeverities: map[string]types.Severity{classDBNoInbound: types.SeverityWarn},
+		Config:          configSchema(defaultDatabaseIsolationConfig),
+	}
+}
+
 func (r *databaseIsolationRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
 	conf := defaultDatabaseIsolationConfig
 	if err := decodeConfig(cfg, &conf); err != nil {
@@ -42,7 +61,9 @@ func (r *databaseIsolationRule) Run(m *model.Architecture, cfg map[string]any) [
 				RuleID:   databaseIsolationRuleID,
 				Severity: types.SeverityError,
 				Message:  fmt.Sprintf("database %s must not initiate relations", rel.From),
+				Class:    classDBInitiates,
 				Path:     relRef.Path,
+				Location: relRef.Location(),
 			})
 		}
 		toRef, okTo := containerIndex[rel.To]
@@ -61,7 +82,9 @@ func (r *databaseIsolationRule) Run(m *model.Architecture, cfg map[string]any) [
 					RuleID:   databaseIsolationRuleID,
 					Severity: types.SeverityWarn,
 					Message:  fmt.Sprintf("database %s has no inbound relations", ref.Container.Name),
+					Class:    classDBNoInbound,
 					Path:     ref.Path,
+					Location: ref.Location(),
 				})
 			}
 		}
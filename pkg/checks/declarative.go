@@ -0,0 +1,322 @@
+package checks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// RuleDefinition declares a rule without Go code. Exactly one of
+// Match.Container or Match.Relation must be set; every matching element is
+// reported with the configured severity and message template.
+type RuleDefinition struct {
+	ID          string         `yaml:"id"`
+	Description string         `yaml:"description"`
+	Severity    types.Severity `yaml:"severity"`
+	Message     string         `yaml:"message"`
+	Match       Match          `yaml:"match"`
+}
+
+// Match selects the elements a declarative rule reports.
+type Match struct {
+	Container *ContainerSelector `yaml:"container"`
+	Relation  *RelationSelector  `yaml:"relation"`
+}
+
+// ContainerSelector matches containers. Empty fields match anything; list
+// fields match when any entry matches.
+type ContainerSelector struct {
+	Names        []string              `yaml:"name"`
+	Types        []model.ContainerType `yaml:"type"`
+	Tags         []string              `yaml:"tags"`
+	Boundaries   []string              `yaml:"boundary"`
+	BoundaryTags []string              `yaml:"boundaryTags"`
+	Technologies []string              `yaml:"technology"`
+	// Meta requires every key to be present with the given value; "*" only
+	// requires presence.
+	Meta map[string]string `yaml:"meta"`
+}
+
+// RelationSelector matches relations by their own attributes and endpoints.
+type RelationSelector struct {
+	Kinds []model.RelationKind `yaml:"kind"`
+	// Protocols are case-insensitive prefixes.
+	Protocols     []string           `yaml:"protocol"`
+	Tags          []string           `yaml:"tags"`
+	CrossBoundary *bool              `yaml:"crossBoundary"`
+	From          *ContainerSelector `yaml:"from"`
+	To            *ContainerSelector `yaml:"to"`
+}
+
+// MessageData is available to message templates, e.g. {{.From}} or {{.Container}}.
+type MessageData struct {
+	Rule         string
+	Container    string
+	Boundary     string
+	From         string
+	To           string
+	FromBoundary string
+	ToBoundary   string
+	Kind         string
+	Protocol     string
+}
+
+type declarativeRule struct {
+	def  RuleDefinition
+	tmpl *template.Template
+	err  error
+}
+
+// NewDeclarativeRule builds a rule from def. An invalid definition yields a
+// rule that reports the problem as a configuration finding.
+func NewDeclarativeRule(def RuleDefinition) Rule {
+	rule := &declarativeRule{def: def}
+	rule.tmpl, rule.err = compileDefinition(def)
+	return rule
+}
+
+// ValidateDefinition reports why def cannot be used as a rule.
+func ValidateDefinition(def RuleDefinition) error {
+	_, err := compileDefinition(def)
+	return err
+}
+
+func compileDefinition(def RuleDefinition) (*template.Template, error) {
+	if strings.TrimSpace(def.ID) == "" {
+		return nil, errors.New("id is required")
+	}
+	if !def.Severity.Valid() {
+		return nil, fmt.Errorf("invalid severity %q", def.Severity)
+	}
+	if (def.Match.Container == nil) == (def.Match.Relation == nil) {
+		return nil, errors.New("match must define exactly one of container or relation")
+	}
+	if def.Message == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(def.ID).Option("missingkey=error").Parse(def.Message)
+	if err != nil {
+		return nil, fmt.Errorf("message template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func (r *declarativeRule) ID() string { return r.def.ID }
+
+func (r *declarativeRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              r.def.ID,
+		Description:     r.def.Description,
+		DefaultSeverity: r.def.Severity,
+	}
+}
+
+func (r *declarativeRule) Run(m *model.Architecture, _ map[string]any) []types.Finding {
+	if r.err != nil {
+		return []types.Finding{configFinding(r.def.ID, r.err)}
+	}
+
+	chains := boundaryChains(m)
+	findings := make([]types.Finding, 0)
+
+	if sel := r.def.Match.Container; sel != nil {
+		for _, ref := range m.Containers() {
+			if !sel.matches(ref, chains) {
+				continue
+			}
+			data := MessageData{Rule: r.def.ID, Container: ref.Container.Name, Boundary: boundaryName(ref.Boundary)}
+			findings = append(findings, types.Finding{
+				RuleID:   r.def.ID,
+				Severity: r.def.Severity,
+				Message:  r.message(data, fmt.Sprintf("container %s matches forbidden selector", ref.Container.Name)),
+				Path:     ref.Path,
+				Location: ref.Location(),
+				Meta: map[string]any{
+					"container": ref.Container.Name,
+				},
+			})
+		}
+	}
+
+	if sel := r.def.Match.Relation; sel != nil {
+		containerIndex := m.ContainerMap()
+		for _, relRef := range m.Relations() {
+			rel := relRef.Relation
+			from, okFrom := containerIndex[rel.From]
+			to, okTo := containerIndex[rel.To]
+			if !okFrom || !okTo || !sel.matches(rel, from, to, chains) {
+				continue
+			}
+			data := MessageData{
+				Rule:         r.def.ID,
+				From:         rel.From,
+				To:           rel.To,
+				FromBoundary: boundaryName(from.Boundary),
+				ToBoundary:   boundaryName(to.Boundary),
+				Kind:         string(rel.Kind),
+				Protocol:     rel.Protocol,
+			}
+			findings = append(findings, types.Finding{
+				RuleID:   r.def.ID,
+				Severity: r.def.Severity,
+				Message:  r.message(data, fmt.Sprintf("relation from %s to %s matches forbidden selector", rel.From, rel.To)),
+				Path:     relRef.Path,
+				Location: relRef.Location(),
+				Meta: map[string]any{
+					"from": rel.From,
+					"to":   rel.To,
+				},
+			})
+		}
+	}
+
+	return findings
+}
+
+func (r *declarativeRule) message(data MessageData, fallback string) string {
+	if r.tmpl == nil {
+		return fallback
+	}
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, data); err != nil {
+		return fallback + " (message template: " + err.Error() + ")"
+	}
+	return buf.String()
+}
+
+func (s *ContainerSelector) matches(ref model.ContainerRef, chains map[*model.Boundary][]*model.Boundary) bool {
+	c := ref.Container
+	if len(s.Names) > 0 && !containsFold(s.Names, c.Name) {
+		return false
+	}
+	if len(s.Types) > 0 {
+		found := false
+		for _, t := range s.Types {
+			if t == c.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(s.Tags) > 0 && !hasTag(c.Tags, toStringSet(s.Tags)) {
+		return false
+	}
+	if len(s.Technologies) > 0 && !containsFold(s.Technologies, c.Technology) {
+		return false
+	}
+	chain := chains[ref.Boundary]
+	if len(s.Boundaries) > 0 {
+		found := false
+		for _, b := range chain {
+			if containsFold(s.Boundaries, b.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(s.BoundaryTags) > 0 {
+		allowed := toStringSet(s.BoundaryTags)
+		found := false
+		for _, b := range chain {
+			if hasTag(b.Tags, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for key, want := range s.Meta {
+		got, ok := c.Meta[key]
+		if !ok || (want != "*" && got != want) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *RelationSelector) matches(rel *model.Relation, from, to model.ContainerRef, chains map[*model.Boundary][]*model.Boundary) bool {
+	if len(s.Kinds) > 0 {
+		found := false
+		for _, k := range s.Kinds {
+			if k == rel.Kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(s.Protocols) > 0 {
+		found := false
+		lower := strings.ToLower(rel.Protocol)
+		for _, prefix := range s.Protocols {
+			if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(s.Tags) > 0 && !hasTag(rel.Tags, toStringSet(s.Tags)) {
+		return false
+	}
+	if s.CrossBoundary != nil && (from.Boundary != to.Boundary) != *s.CrossBoundary {
+		return false
+	}
+	if s.From != nil && !s.From.matches(from, chains) {
+		return false
+	}
+	if s.To != nil && !s.To.matches(to, chains) {
+		return false
+	}
+	return true
+}
+
+// boundaryChains maps every boundary to itself plus its ancestors, innermost first.
+func boundaryChains(m *model.Architecture) map[*model.Boundary][]*model.Boundary {
+	chains := map[*model.Boundary][]*model.Boundary{}
+	var visit func(b *model.Boundary, parents []*model.Boundary)
+	visit = func(b *model.Boundary, parents []*model.Boundary) {
+		chain := append([]*model.Boundary{b}, parents...)
+		chains[b] = chain
+		for i := range b.Boundaries {
+			visit(&b.Boundaries[i], chain)
+		}
+	}
+	for i := range m.Boundaries {
+		visit(&m.Boundaries[i], nil)
+	}
+	return chains
+}
+
+func boundaryName(b *model.Boundary) string {
+	if b == nil {
+		return ""
+	}
+	return b.Name
+}
+
+func containsFold(values []string, v string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -8,13 +8,19 @@ import (
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-const externalProtocolRuleID = "ARCH-EXTERNAL-PROTOCOL"
+const (
+	externalProtocolRuleID = "ARCH-EXTERNAL-PROTOCOL"
+
+	// Message classes, usable as severity override keys.
+	classProtocolMissing = "missing-protocol"
+	classProtocolDenied  = "protocol-not-allowed"
+)
 
 type externalProtocolRule struct{}
 
 type externalProtocolConfig struct {
-	AllowedPrefixes []string `json:"allowedPrefixes"`
-	RequireProtocol bool     `json:"requireProtocol"`
+	AllowedPrefixes []string `json:"allowedPrefixes" doc:"Protocol prefixes allowed on relations to externals."`
+	RequireProtocol bool     `json:"requireProtocol" doc:"Report relations to externals that declare no protocol."`
 }
 
 var defaultExternalProtocolConfig = externalProtocolConfig{
@@ -30,6 +36,18 @@ func NewExternalProtocolRule() Rule { return &externalProtocolRule{} }
 
 func (r *externalProtocolRule) ID() string { return externalProtocolRuleID }
 
+func (r *externalProtocolRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              externalProtocolRuleID,
+		Description:     "Relations to externals must use an approved protocol prefix.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "Approved gateways and transports give external traffic consistent authentication, observability and rate limiting.",
+		DocURL:          types.RuleDocURL(externalProtocolRuleID),
+		Classes:         []string{classProtocolMissing, classProtocolDenied},
+		Config:          configSchema(defaultExternalProtocolConfig),
+	}
+}
+
 func (r *externalProtocolRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
 	conf := defaultExternalProtocolConfig
 	if err := decodeConfig(cfg, &conf); err != nil {
@@ -52,7 +70,9 @@ func (r *externalProtocolRule) Run(m *model.Architecture, cfg map[string]any) []
 					RuleID:   externalProtocolRuleID,
 					Severity: types.SeverityError,
 					Message:  fmt.Sprintf("relation from %s to external %s must define protocol", rel.From, rel.To),
+					Class:    classProtocolMissing,
 					Path:     relRef.Path,
+					Location: relRef.Location(),
 				})
 			}
 			continue
@@ -73,7 +93,9 @@ func (r *externalProtocolRule) Run(m *model.Architecture, cfg map[string]any) []
 				RuleID:   externalProtocolRuleID,
 				Severity: types.SeverityError,
 				Message:  fmt.Sprintf("protocol %q for external %s is not allowed", protocol, rel.To),
+				Class:    classProtocolDenied,
 				Path:     relRef.Path + ".protocol",
+				Location: relRef.Location(),
 				Meta: map[string]any{
 					"allowedPrefixes": conf.AllowedPrefixes,
 				},
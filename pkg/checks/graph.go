@@ -0,0 +1,178 @@
+package checks
+
+import (
+	"sort"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// dependencyGraph is an adjacency list over container names with sorted,
+// de-duplicated targets, so every traversal below is deterministic.
+type dependencyGraph map[string][]string
+
+func newDependencyGraph(relations []model.RelationRef, keep func(*model.Relation) bool) dependencyGraph {
+	seen := map[[2]string]bool{}
+	g := dependencyGraph{}
+	for _, ref := range relations {
+		rel := ref.Relation
+		if keep != nil && !keep(rel) {
+			continue
+		}
+		key := [2]string{rel.From, rel.To}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		g[rel.From] = append(g[rel.From], rel.To)
+		if _, ok := g[rel.To]; !ok {
+			g[rel.To] = nil
+		}
+	}
+	for _, targets := range g {
+		sort.Strings(targets)
+	}
+	return g
+}
+
+func (g dependencyGraph) nodes() []string {
+	nodes := make([]string, 0, len(g))
+	for node := range g {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// cycles returns the strongly connected components that contain a cycle
+// (more than one member, or a self-loop), found with Tarjan's algorithm.
+// Members are sorted and components are ordered by their first member.
+func (g dependencyGraph) cycles() [][]string {
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	stack := make([]string, 0)
+	components := make([][]string, 0)
+
+	var connect func(node string)
+	connect = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range g[node] {
+			if _, visited := index[next]; !visited {
+				connect(next)
+				lowlink[node] = min(lowlink[node], lowlink[next])
+			} else if onStack[next] {
+				lowlink[node] = min(lowlink[node], index[next])
+			}
+		}
+
+		if lowlink[node] != index[node] {
+			return
+		}
+		component := make([]string, 0)
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 || g.hasEdge(node, node) {
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range g.nodes() {
+		if _, visited := index[node]; !visited {
+			connect(node)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+func (g dependencyGraph) hasEdge(from, to string) bool {
+	for _, next := range g[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// shortestCycle returns the shortest cycle through start that stays within
+// members, as a closed walk beginning and ending at start.
+func (g dependencyGraph) shortestCycle(start string, members map[string]struct{}) []string {
+	parent := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range g[node] {
+			if _, ok := members[next]; !ok {
+				continue
+			}
+			if next == start {
+				cycle := []string{start}
+				for at := node; at != start; at = parent[at] {
+					cycle = append(cycle, at)
+				}
+				cycle = append(cycle, start)
+				for i, j := 1, len(cycle)-2; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return cycle
+			}
+			if _, seen := parent[next]; !seen {
+				parent[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return []string{start}
+}
+
+// longestPath returns the longest simple path starting at start, preferring
+// the alphabetically first path among equally long ones. Nodes inside a
+// cycle are searched exhaustively with backtracking; a node outside every
+// cycle cannot reach the path that led to it, so its result is memoized.
+func (g dependencyGraph) longestPath(start string) []string {
+	cyclic := map[string]bool{}
+	for _, component := range g.cycles() {
+		for _, node := range component {
+			cyclic[node] = true
+		}
+	}
+	memo := map[string][]string{}
+	onPath := map[string]bool{}
+
+	var walk func(node string) []string
+	walk = func(node string) []string {
+		if path, ok := memo[node]; ok {
+			return path
+		}
+		onPath[node] = true
+		var best []string
+		for _, next := range g[node] {
+			if onPath[next] {
+				continue
+			}
+			if tail := walk(next); len(tail) > len(best) {
+				best = tail
+			}
+		}
+		onPath[node] = false
+		path := append([]string{node}, best...)
+		if !cyclic[node] {
+			memo[node] = path
+		}
+		return path
+	}
+	return walk(start)
+}
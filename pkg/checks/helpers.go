@@ -2,11 +2,16 @@ package checks
 
 import "github.com/PET-dev-projects/ArchLint/pkg/types"
 
+// ClassConfig marks findings about invalid rule configuration. Rule-level
+// severity overrides do not apply to them.
+const ClassConfig = "config"
+
 func configFinding(ruleID string, err error) types.Finding {
 	return types.Finding{
 		RuleID:   ruleID,
 		Severity: types.SeverityError,
 		Message:  "invalid rule configuration: " + err.Error(),
 		Path:     "options.ruleConfig[" + ruleID + "]",
+		Class:    ClassConfig,
 	}
 }
@@ -0,0 +1,123 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+const (
+	layersRuleID = "ARCH-LAYERS"
+
+	// Message classes, usable as severity override keys.
+	classLayersUpward = "upward"
+	classLayersSkip   = "skip"
+)
+
+type layersRule struct{}
+
+type layerConfig struct {
+	Name  string                `json:"name"`
+	Tags  []string              `json:"tags"`
+	Types []model.ContainerType `json:"types"`
+}
+
+type layersConfig struct {
+	Layers []layerConfig `json:"layers" doc:"Layers from top to bottom; each has a name plus tags and/or types that place a container in it."`
+	Strict bool          `json:"strict" doc:"Also report relations that skip an intermediate layer."`
+}
+
+var defaultLayersConfig = layersConfig{}
+
+// NewLayersRule enforces top-down dependencies between tagged layers.
+func NewLayersRule() Rule { return &layersRule{} }
+
+func (r *layersRule) ID() string { return layersRuleID }
+
+func (r *layersRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              layersRuleID,
+		Description:     "Relations must point down the configured layer order.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "Dependencies that only point downwards keep inner layers independent of delivery concerns and let each layer change without rippling upwards.",
+		DocURL:          types.RuleDocURL(layersRuleID),
+		Classes:         []string{classLayersUpward, classLayersSkip},
+		Config:          configSchema(defaultLayersConfig),
+	}
+}
+
+func (r *layersRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
+	conf := defaultLayersConfig
+	if err := decodeConfig(cfg, &conf); err != nil {
+		return []types.Finding{configFinding(layersRuleID, err)}
+	}
+	for idx, layer := range conf.Layers {
+		if layer.Name == "" {
+			return []types.Finding{configFinding(layersRuleID, fmt.Errorf("layers[%d]: name is required", idx))}
+		}
+		if len(layer.Tags) == 0 && len(layer.Types) == 0 {
+			return []types.Finding{configFinding(layersRuleID, errors.New("layer "+layer.Name+" needs tags or types"))}
+		}
+	}
+
+	containerIndex := m.ContainerMap()
+	findings := make([]types.Finding, 0)
+	for _, relRef := range m.Relations() {
+		rel := relRef.Relation
+		from, okFrom := containerIndex[rel.From]
+		to, okTo := containerIndex[rel.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		fromLayer := layerOf(conf.Layers, from.Container)
+		toLayer := layerOf(conf.Layers, to.Container)
+		if fromLayer < 0 || toLayer < 0 {
+			continue
+		}
+
+		var message, class string
+		switch {
+		case toLayer < fromLayer:
+			class = classLayersUpward
+			message = fmt.Sprintf("relation from %s (%s) to %s (%s) points up the layer order",
+				rel.From, conf.Layers[fromLayer].Name, rel.To, conf.Layers[toLayer].Name)
+		case conf.Strict && toLayer > fromLayer+1:
+			class = classLayersSkip
+			message = fmt.Sprintf("relation from %s (%s) to %s (%s) skips layer %s",
+				rel.From, conf.Layers[fromLayer].Name, rel.To, conf.Layers[toLayer].Name, conf.Layers[fromLayer+1].Name)
+		default:
+			continue
+		}
+		findings = append(findings, types.Finding{
+			RuleID:   layersRuleID,
+			Severity: types.SeverityError,
+			Message:  message,
+			Class:    class,
+			Path:     relRef.Path,
+			Location: relRef.Location(),
+			Meta: map[string]any{
+				"fromLayer": conf.Layers[fromLayer].Name,
+				"toLayer":   conf.Layers[toLayer].Name,
+			},
+		})
+	}
+
+	return findings
+}
+
+// layerOf returns the index of the first layer matching c, or -1.
+func layerOf(layers []layerConfig, c *model.Container) int {
+	for idx, layer := range layers {
+		if hasTag(c.Tags, toStringSet(layer.Tags)) {
+			return idx
+		}
+		for _, t := range layer.Types {
+			if t == c.Type {
+				return idx
+			}
+		}
+	}
+	return -1
+}
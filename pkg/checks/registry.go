@@ -1,5 +1,7 @@
 package checks
 
+import "github.com/PET-dev-projects/ArchLint/pkg/types"
+
 // Registry exposes default rules used by the engine.
 type Registry struct {
 	rules []Rule
@@ -11,10 +13,15 @@ func DefaultRegistry() Registry {
 		rules: []Rule{
 			NewAcyclicRule(),
 			NewCRUDRule(),
+			NewLayersRule(),
 			NewACLRule(),
 			NewBoundariesRule(),
 			NewExternalProtocolRule(),
 			NewDatabaseIsolationRule(),
+			NewCouplingRule(),
+			NewCallDepthRule(),
+			NewSharedDatabaseRule(),
+			NewUnusedInterfaceRule(),
 		},
 	}
 }
@@ -24,6 +31,13 @@ func (r Registry) Rules() []Rule {
 	return r.rules
 }
 
+// With returns a copy of the registry with extra rules appended.
+func (r Registry) With(rules ...Rule) Registry {
+	combined := make([]Rule, 0, len(r.rules)+len(rules))
+	combined = append(combined, r.rules...)
+	return Registry{rules: append(combined, rules...)}
+}
+
 // Find looks up rule by ID.
 func (r Registry) Find(id string) (Rule, bool) {
 	for _, rule := range r.rules {
@@ -33,3 +47,12 @@ func (r Registry) Find(id string) (Rule, bool) {
 	}
 	return nil, false
 }
+
+// Metadata returns descriptions of every registered rule.
+func (r Registry) Metadata() []types.RuleMetadata {
+	meta := make([]types.RuleMetadata, 0, len(r.rules))
+	for _, rule := range r.rules {
+		meta = append(meta, rule.Metadata())
+	}
+	return meta
+}
@@ -0,0 +1,156 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+const (
+	sharedDatabaseRuleID = "ARCH-SHARED-DB"
+
+	// Message classes, usable as severity override keys.
+	classSharedDBBoundaries = "multi-boundary"
+	classSharedDBAccessors  = "accessors"
+)
+
+type sharedDatabaseRule struct{}
+
+type sharedDatabaseConfig struct {
+	MaxBoundaries    int      `json:"maxBoundaries" doc:"Maximum distinct boundaries whose containers access one database; 0 disables the check."`
+	MaxAccessors     int      `json:"maxAccessors" doc:"Maximum distinct containers accessing one database; 0 disables the check."`
+	ExemptTags       []string `json:"exemptTags" doc:"Database tags that allow sharing."`
+	NestedBoundaries bool     `json:"nestedBoundaries" doc:"Count nested boundaries separately instead of attributing accessors to their top-level boundary."`
+}
+
+var defaultSharedDatabaseConfig = sharedDatabaseConfig{
+	MaxBoundaries: 1,
+	ExemptTags:    []string{"shared-readonly"},
+}
+
+// NewSharedDatabaseRule flags databases used across boundaries or by too many
+// containers.
+func NewSharedDatabaseRule() Rule { return &sharedDatabaseRule{} }
+
+func (r *sharedDatabaseRule) ID() string { return sharedDatabaseRuleID }
+
+func (r *sharedDatabaseRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              sharedDatabaseRuleID,
+		Description:     "A database is accessed from a single boundary and by a limited number of containers.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "A shared database is an undocumented integration contract: every accessor couples to the schema, so nobody can change it alone.",
+		DocURL:          types.RuleDocURL(sharedDatabaseRuleID),
+		Classes:         []string{classSharedDBBoundaries, classSharedDBAccessors},
+		Config:          configSchema(defaultSharedDatabaseConfig),
+	}
+}
+
+type databaseAccessor struct {
+	container string
+	boundary  string
+	path      string
+}
+
+func (r *sharedDatabaseRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
+	conf := defaultSharedDatabaseConfig
+	if err := decodeConfig(cfg, &conf); err != nil {
+		return []types.Finding{configFinding(sharedDatabaseRuleID, err)}
+	}
+
+	containerIndex := m.ContainerMap()
+	accessors := map[string]map[string]databaseAccessor{}
+	for _, relRef := range m.Relations() {
+		rel := relRef.Relation
+		from, okFrom := containerIndex[rel.From]
+		to, okTo := containerIndex[rel.To]
+		if !okFrom || !okTo || to.Container.Type != model.ContainerDatabase || rel.From == rel.To {
+			continue
+		}
+		if accessors[rel.To] == nil {
+			accessors[rel.To] = map[string]databaseAccessor{}
+		}
+		accessor := databaseAccessor{
+			container: rel.From,
+			boundary:  boundaryName(from.Boundary),
+			path:      from.BoundaryPath,
+		}
+		if !conf.NestedBoundaries {
+			accessor.path, accessor.boundary = topLevelBoundary(m, from.BoundaryPath)
+		}
+		accessors[rel.To][rel.From] = accessor
+	}
+
+	exempt := toStringSet(conf.ExemptTags)
+	findings := make([]types.Finding, 0)
+	for _, ref := range m.Containers() {
+		db := ref.Container
+		if db.Type != model.ContainerDatabase || hasTag(db.Tags, exempt) || len(accessors[db.Name]) == 0 {
+			continue
+		}
+
+		list := make([]databaseAccessor, 0, len(accessors[db.Name]))
+		boundaries := map[string]string{}
+		for _, a := range accessors[db.Name] {
+			list = append(list, a)
+			boundaries[a.path] = a.boundary
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].container < list[j].container })
+		described := make([]string, 0, len(list))
+		meta := make([]map[string]string, 0, len(list))
+		for _, a := range list {
+			described = append(described, fmt.Sprintf("%s (%s)", a.container, orNone(a.boundary)))
+			meta = append(meta, map[string]string{"container": a.container, "boundary": a.boundary})
+		}
+		names := make([]string, 0, len(boundaries))
+		for _, name := range boundaries {
+			names = append(names, orNone(name))
+		}
+		sort.Strings(names)
+
+		report := func(class, message string) {
+			findings = append(findings, types.Finding{
+				RuleID:   sharedDatabaseRuleID,
+				Severity: types.SeverityError,
+				Message:  message,
+				Class:    class,
+				Path:     ref.Path,
+				Location: ref.Location(),
+				Meta: map[string]any{
+					"accessors":  meta,
+					"boundaries": names,
+				},
+			})
+		}
+		if conf.MaxBoundaries > 0 && len(boundaries) > conf.MaxBoundaries {
+			report(classSharedDBBoundaries, fmt.Sprintf("database %s is shared by %d boundaries (max %d): %s",
+				db.Name, len(boundaries), conf.MaxBoundaries, strings.Join(described, ", ")))
+		}
+		if conf.MaxAccessors > 0 && len(list) > conf.MaxAccessors {
+			report(classSharedDBAccessors, fmt.Sprintf("database %s is accessed by %d containers (max %d): %s",
+				db.Name, len(list), conf.MaxAccessors, strings.Join(described, ", ")))
+		}
+	}
+	return findings
+}
+
+// topLevelBoundary returns the path and name of the top-level boundary that
+// contains the boundary at path.
+func topLevelBoundary(m *model.Architecture, path string) (string, string) {
+	top, _, _ := strings.Cut(path, ".")
+	var idx int
+	if _, err := fmt.Sscanf(top, "boundaries[%d]", &idx); err != nil || idx < 0 || idx >= len(m.Boundaries) {
+		return path, ""
+	}
+	return top, m.Boundaries[idx].Name
+}
+
+func orNone(name string) string {
+	if name == "" {
+		return "no boundary"
+	}
+	return name
+}
@@ -0,0 +1,73 @@
+package checks
+
+import (
+	"sort"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// BoundaryStats summarises the relations of one boundary, nested boundaries
+// included, as used by ARCH-BOUNDARIES.
+type BoundaryStats struct {
+	Name string
+	Path string
+	// Containers lists the names of the boundary's containers, nested ones included.
+	Containers []string
+	// Internal and Cross count outgoing relations of those containers whose
+	// target is inside or outside the boundary.
+	Internal int
+	Cross    int
+	// Inbound counts relations from outside the boundary targeting it.
+	Inbound int
+}
+
+// CollectBoundaryStats returns statistics for every boundary in declaration
+// order, parents before their nested boundaries.
+func CollectBoundaryStats(m *model.Architecture) []BoundaryStats {
+	metrics := collectBoundaryMetrics(m)
+	stats := make([]BoundaryStats, 0, len(metrics))
+	for _, metric := range metrics {
+		names := make([]string, 0, len(metric.containers))
+		for name := range metric.containers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		stats = append(stats, BoundaryStats{
+			Name:       metric.name,
+			Path:       metric.path,
+			Containers: names,
+			Internal:   metric.internal,
+			Cross:      metric.cross,
+			Inbound:    metric.inbound,
+		})
+	}
+	return stats
+}
+
+// Cycles returns the groups of containers that depend on each other through
+// relations of the given kinds (all kinds when none are given). Each group is
+// a strongly connected component with sorted members.
+func Cycles(m *model.Architecture, kinds ...model.RelationKind) [][]string {
+	allowed := map[model.RelationKind]bool{}
+	for _, kind := range kinds {
+		allowed[kind] = true
+	}
+	return newDependencyGraph(m.Relations(), func(rel *model.Relation) bool {
+		return len(allowed) == 0 || allowed[rel.Kind]
+	}).cycles()
+}
+
+// LongestSyncChain returns the longest chain of containers connected by
+// sync relations, or nil when the model has none.
+func LongestSyncChain(m *model.Architecture) []string {
+	g := newDependencyGraph(m.Relations(), func(rel *model.Relation) bool {
+		return rel.Kind == model.RelationKindSync
+	})
+	var longest []string
+	for _, node := range g.nodes() {
+		if chain := g.longestPath(node); len(chain) > 1 && len(chain) > len(longest) {
+			longest = chain
+		}
+	}
+	return longest
+}
@@ -0,0 +1,99 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+const (
+	unusedInterfaceRuleID = "ARCH-UNUSED-INTERFACE"
+
+	// Message classes, usable as severity override keys.
+	classUnusedInterface = "unused"
+)
+
+type unusedInterfaceRule struct{}
+
+type unusedInterfaceConfig struct {
+	ExemptTags       []string `json:"exemptTags" doc:"Container tags whose interfaces may be consumed outside the model."`
+	IncludeExternals bool     `json:"includeExternals" doc:"Also check interfaces provided by external containers."`
+	RequireUses      bool     `json:"requireUses" doc:"Treat relations without uses as consuming no interface; by default they mark every interface of their target as possibly used."`
+}
+
+var defaultUnusedInterfaceConfig = unusedInterfaceConfig{
+	ExemptTags: []string{"edge", "public"},
+}
+
+// NewUnusedInterfaceRule flags provided interfaces no relation uses.
+func NewUnusedInterfaceRule() Rule { return &unusedInterfaceRule{} }
+
+func (r *unusedInterfaceRule) ID() string { return unusedInterfaceRuleID }
+
+func (r *unusedInterfaceRule) Metadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              unusedInterfaceRuleID,
+		Description:     "Every interface a container provides is used by at least one relation.",
+		DefaultSeverity: types.SeverityWarn,
+		Rationale:       "An operation or topic nobody consumes is either dead code to remove or a dependency the model forgot to declare.",
+		DocURL:          types.RuleDocURL(unusedInterfaceRuleID),
+		Classes:         []string{classUnusedInterface},
+		Config:          configSchema(defaultUnusedInterfaceConfig),
+	}
+}
+
+func (r *unusedInterfaceRule) Run(m *model.Architecture, cfg map[string]any) []types.Finding {
+	conf := defaultUnusedInterfaceConfig
+	if err := decodeConfig(cfg, &conf); err != nil {
+		return []types.Finding{configFinding(unusedInterfaceRuleID, err)}
+	}
+
+	used := map[string]map[string]struct{}{}
+	untyped := map[string]struct{}{}
+	for _, relRef := range m.Relations() {
+		rel := relRef.Relation
+		if rel.Uses == "" {
+			untyped[rel.To] = struct{}{}
+			continue
+		}
+		if used[rel.To] == nil {
+			used[rel.To] = map[string]struct{}{}
+		}
+		used[rel.To][rel.Uses] = struct{}{}
+	}
+
+	exempt := toStringSet(conf.ExemptTags)
+	findings := make([]types.Finding, 0)
+	for _, ref := range m.Containers() {
+		c := ref.Container
+		if len(c.Provides) == 0 || hasTag(c.Tags, exempt) {
+			continue
+		}
+		if c.Type == model.ContainerExternal && !conf.IncludeExternals {
+			continue
+		}
+		if _, ok := untyped[c.Name]; ok && !conf.RequireUses {
+			continue
+		}
+		for i, iface := range c.Provides {
+			if _, ok := used[c.Name][iface.Name]; ok || iface.Name == "" {
+				continue
+			}
+			findings = append(findings, types.Finding{
+				RuleID:   unusedInterfaceRuleID,
+				Severity: types.SeverityWarn,
+				Message:  fmt.Sprintf("%s interface %s of %s is not used by any relation", iface.Kind, iface.Name, c.Name),
+				Class:    classUnusedInterface,
+				Path:     fmt.Sprintf("%s.provides[%d]", ref.Path, i),
+				Location: ref.InterfaceLocation(i),
+				Meta: map[string]any{
+					"container": c.Name,
+					"interface": iface.Name,
+					"kind":      string(iface.Kind),
+				},
+			})
+		}
+	}
+	return findings
+}
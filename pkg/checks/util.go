@@ -33,3 +33,11 @@ func buildOutgoing(m *model.Architecture) relationList {
 	}
 	return res
 }
+
+func buildIncoming(m *model.Architecture) relationList {
+	res := make(relationList)
+	for _, rel := range m.Relations() {
+		res[rel.Relation.To] = append(res[rel.Relation.To], rel)
+	}
+	return res
+}
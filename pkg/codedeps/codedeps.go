@@ -0,0 +1,289 @@
+package codedeps
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// RuleID identifies code dependency findings.
+const RuleID = "ARCH-CODE-DEPS"
+
+// Message classes. Code dependency findings keep their severities; the
+// check config file does not apply to them.
+const (
+	ClassUndeclared       = "undeclared-import"
+	ClassUnknownContainer = "unknown-container"
+)
+
+// RuleMetadata describes code dependency findings.
+func RuleMetadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              RuleID,
+		Description:     "Go imports between containers follow declared relations.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "A diagram only constrains the system if the code agrees with it; an import the model does not know about is an undocumented dependency.",
+		DocURL:          types.RuleDocURL(RuleID),
+		Classes:         []string{ClassUndeclared, ClassUnknownContainer},
+	}
+}
+
+// Config maps Go packages to containers.
+type Config struct {
+	// Root is the directory scanned for Go files; relative paths are resolved
+	// against the config file.
+	Root string `yaml:"root"`
+	// IncludeTests also reads _test.go files.
+	IncludeTests bool `yaml:"includeTests"`
+	// Packages are tried in order; the first matching pattern wins.
+	Packages []PackageMapping `yaml:"packages"`
+}
+
+// PackageMapping assigns packages matching Pattern to Container. Patterns
+// follow the go tool: "example.com/shop/orders/..." matches the package and
+// everything below it, anything else matches one import path exactly.
+type PackageMapping struct {
+	Pattern   string `yaml:"pattern"`
+	Container string `yaml:"container"`
+}
+
+// LoadConfig reads a YAML config file and resolves Root against its
+// directory (default: the directory itself).
+func LoadConfig(path string) (Config, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer fh.Close()
+	var cfg Config
+	dec := yaml.NewDecoder(fh)
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if !filepath.IsAbs(cfg.Root) {
+		cfg.Root = filepath.Join(filepath.Dir(path), cfg.Root)
+	}
+	for i, p := range cfg.Packages {
+		if p.Pattern == "" || p.Container == "" {
+			return Config{}, fmt.Errorf("%s: packages[%d] needs pattern and container", path, i)
+		}
+	}
+	return cfg, nil
+}
+
+func (c Config) containerOf(importPath string) string {
+	for _, p := range c.Packages {
+		if matchPattern(p.Pattern, importPath) {
+			return p.Container
+		}
+	}
+	return ""
+}
+
+func matchPattern(pattern, importPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return importPath == prefix || strings.HasPrefix(importPath, prefix+"/")
+	}
+	return pattern == importPath
+}
+
+// Import is one import statement in a Go file.
+type Import struct {
+	Package string
+	Path    string
+	File    string
+	Line    int
+	Column  int
+}
+
+// ScanImports reads the imports of every Go package below root. Import
+// paths of the scanned packages come from the nearest go.mod; vendor,
+// testdata and directories starting with "." or "_" are skipped.
+func ScanImports(root string, includeTests bool) ([]Import, error) {
+	modules := map[string]string{}
+	imports := make([]Import, 0)
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || (!includeTests && strings.HasSuffix(path, "_test.go")) {
+			return nil
+		}
+		pkg, err := packagePath(filepath.Dir(path), root, modules)
+		if err != nil {
+			return err
+		}
+		if pkg == "" {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, spec := range file.Imports {
+			value, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			pos := fset.Position(spec.Path.Pos())
+			imports = append(imports, Import{Package: pkg, Path: value, File: path, Line: pos.Line, Column: pos.Column})
+		}
+		return nil
+	})
+	return imports, err
+}
+
+// packagePath derives the import path of dir from the nearest go.mod at or
+// above it (but not above root), caching module lookups per directory.
+func packagePath(dir, root string, modules map[string]string) (string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		module, ok := modules[current]
+		if !ok {
+			data, err := os.ReadFile(filepath.Join(current, "go.mod"))
+			switch {
+			case err == nil:
+				module = modulePath(data)
+			case !errors.Is(err, fs.ErrNotExist):
+				return "", err
+			}
+			modules[current] = module
+		}
+		if module != "" {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		if current == root || filepath.Dir(current) == current {
+			return "", nil
+		}
+	}
+}
+
+func modulePath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		line = strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(line, "module"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// Check scans cfg.Root and reports imports between containers that m does
+// not declare a relation for, one finding per container pair.
+func Check(m *model.Architecture, cfg Config) ([]types.Finding, error) {
+	imports, err := ScanImports(cfg.Root, cfg.IncludeTests)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(m, cfg, imports), nil
+}
+
+// Compare reports the imports that cross containers without a declared
+// relation.
+func Compare(m *model.Architecture, cfg Config, imports []Import) []types.Finding {
+	containers := m.ContainerMap()
+	findings := make([]types.Finding, 0)
+
+	unknown := map[string]bool{}
+	for i, p := range cfg.Packages {
+		if _, ok := containers[p.Container]; !ok && !unknown[p.Container] {
+			unknown[p.Container] = true
+			findings = append(findings, types.Finding{
+				RuleID:   RuleID,
+				Severity: types.SeverityError,
+				Message:  fmt.Sprintf("package pattern %s maps to unknown container %s", p.Pattern, p.Container),
+				Class:    ClassUnknownContainer,
+				Path:     fmt.Sprintf("packages[%d]", i),
+			})
+		}
+	}
+
+	declared := map[[2]string]bool{}
+	for _, relRef := range m.Relations() {
+		declared[[2]string{relRef.Relation.From, relRef.Relation.To}] = true
+	}
+
+	type crossing struct {
+		first   Import
+		imports map[string]struct{}
+	}
+	crossings := map[[2]string]*crossing{}
+	for _, imp := range imports {
+		from := cfg.containerOf(imp.Package)
+		to := cfg.containerOf(imp.Path)
+		if from == "" || to == "" || from == to || unknown[from] || unknown[to] {
+			continue
+		}
+		key := [2]string{from, to}
+		if declared[key] {
+			continue
+		}
+		c, ok := crossings[key]
+		if !ok {
+			c = &crossing{first: imp, imports: map[string]struct{}{}}
+			crossings[key] = c
+		}
+		c.imports[imp.Package+" -> "+imp.Path] = struct{}{}
+	}
+
+	keys := make([][2]string, 0, len(crossings))
+	for key := range crossings {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		c := crossings[key]
+		list := make([]string, 0, len(c.imports))
+		for imp := range c.imports {
+			list = append(list, imp)
+		}
+		sort.Strings(list)
+		findings = append(findings, types.Finding{
+			RuleID:   RuleID,
+			Severity: types.SeverityError,
+			Message:  fmt.Sprintf("%s imports %s (%s) but no relation from %s to %s is declared", c.first.Package, c.first.Path, key[1], key[0], key[1]),
+			Class:    ClassUndeclared,
+			Path:     containers[key[0]].Path,
+			Location: &types.Location{File: c.first.File, Line: c.first.Line, Column: c.first.Column},
+			Meta: map[string]any{
+				"from":    key[0],
+				"to":      key[1],
+				"imports": list,
+			},
+		})
+	}
+	return findings
+}
@@ -0,0 +1,51 @@
+package codedeps_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/codedeps"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+func TestCheck(t *testing.T) {
+	dir := filepath.Join("..", "..", "testdata", "gomono")
+	arch, err := model.LoadModelFromFile(filepath.Join(dir, "arch.yaml"))
+	if err != nil {
+		t.Fatalf("load model: %v", err)
+	}
+	cfg, err := codedeps.LoadConfig(filepath.Join(dir, "archlint-code.yaml"))
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	findings, err := codedeps.Check(arch, cfg)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Meta["from"].(string)+">"+f.Meta["to"].(string))
+	}
+	if strings.Join(got, " ") != "billing>orders orders>catalog" {
+		t.Fatalf("unexpected findings: %v", findings)
+	}
+	loc := findings[0].Location
+	if loc == nil || filepath.Base(loc.File) != "billing.go" || loc.Line != 3 || findings[0].Path != "boundaries[0].containers[1]" {
+		t.Fatalf("expected import position in billing.go, got %v", findings[0])
+	}
+	if imports := findings[0].Meta["imports"].([]string); len(imports) != 1 || imports[0] != "example.com/shop/billing -> example.com/shop/orders/api" {
+		t.Fatalf("unexpected imports meta: %v", imports)
+	}
+
+	cfg.IncludeTests = true
+	cfg.Packages = append(cfg.Packages, codedeps.PackageMapping{Pattern: "example.com/tools", Container: "tooling"})
+	findings, err = codedeps.Check(arch, cfg)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(findings) != 4 || findings[0].Class != codedeps.ClassUnknownContainer || findings[1].Meta["from"] != "billing" || findings[2].Meta["to"] != "billing" {
+		t.Fatalf("expected unknown container and test import findings, got %v", findings)
+	}
+}
@@ -0,0 +1,6 @@
+// Package codedeps checks Go source code against an architecture model.
+// Packages are assigned to containers by import path patterns, imports are
+// read with go/parser (no build or type checking needed), and every import
+// between two containers without a declared relation is reported as an
+// ARCH-CODE-DEPS finding.
+package codedeps
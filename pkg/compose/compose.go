@@ -0,0 +1,269 @@
+package compose
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PET-dev-projects/ArchLint/internal/images"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// DefaultName names the boundary when neither Options.Name nor the compose
+// file's top-level name is set.
+const DefaultName = "compose"
+
+// metaImage records the source image of an imported container.
+const metaImage = "compose.image"
+
+// urlPattern finds scheme://[user@]host URLs in environment values.
+var urlPattern = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*)://(?:[^@/\s]*@)?([A-Za-z0-9_.-]+)(:[0-9]+)?`)
+
+// Options tune the import.
+type Options struct {
+	// Name is the boundary name; it defaults to the compose project name.
+	Name string
+}
+
+// Warning describes a construct that could not be converted faithfully.
+type Warning struct {
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (w Warning) String() string {
+	if w.Line > 0 {
+		return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+	}
+	return w.Message
+}
+
+type service struct {
+	name    string
+	node    *yaml.Node
+	image   string
+	kind    model.RelationKind
+	aliases []string
+}
+
+type importer struct {
+	services []*service
+	byName   map[string]*service
+	warnings []Warning
+}
+
+// Import converts a docker-compose file into an architecture model.
+func Import(r io.Reader, opts Options) (*model.Architecture, []Warning, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("compose file is empty")
+		}
+		return nil, nil, err
+	}
+	root := &doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, errors.New("compose file must be a mapping")
+	}
+	services := mappingValue(root, "services")
+	if services == nil || services.Kind != yaml.MappingNode || len(services.Content) == 0 {
+		return nil, nil, errors.New("compose file declares no services")
+	}
+
+	name := opts.Name
+	if name == "" {
+		if n := mappingValue(root, "name"); n != nil && n.Value != "" {
+			name = n.Value
+		} else {
+			name = DefaultName
+		}
+	}
+
+	imp := &importer{byName: map[string]*service{}}
+	boundary := model.Boundary{Name: name, Containers: []model.Container{}}
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		key, body := services.Content[i], services.Content[i+1]
+		s := &service{name: key.Value, node: body, kind: model.RelationKindSync}
+		c := model.Container{Name: s.name, Type: model.ContainerService}
+		if image := mappingValue(body, "image"); image != nil && image.Value != "" {
+			s.image = image.Value
+			c.Meta = model.Metadata{metaImage: s.image}
+			if tech, ok := images.Database(s.image); ok {
+				c.Type = model.ContainerDatabase
+				c.Technology = tech
+				s.kind = model.RelationKindDB
+			} else if tech, ok := images.Broker(s.image); ok {
+				c.Technology = tech
+				c.Tags = []string{"broker"}
+				s.kind = model.RelationKindAsync
+			}
+		}
+		s.aliases = networkAliases(body)
+		imp.services = append(imp.services, s)
+		imp.byName[s.name] = s
+		boundary.Containers = append(boundary.Containers, c)
+	}
+
+	for _, s := range imp.services {
+		boundary.Relations = append(boundary.Relations, imp.relations(s)...)
+	}
+	return &model.Architecture{Version: 1, Boundaries: []model.Boundary{boundary}}, imp.warnings, nil
+}
+
+func (imp *importer) relations(s *service) []model.Relation {
+	rels := make([]model.Relation, 0)
+	index := map[string]int{}
+	// add records one relation per target; later sources only fill fields
+	// earlier ones left empty, so depends_on plus a URL keeps the protocol.
+	add := func(target *service, description, protocol string) {
+		if target == s {
+			return
+		}
+		if idx, ok := index[target.name]; ok {
+			rel := &rels[idx]
+			if rel.Protocol == "" {
+				rel.Protocol = protocol
+			}
+			if rel.Description == "" {
+				rel.Description = description
+			}
+			return
+		}
+		index[target.name] = len(rels)
+		rels = append(rels, model.Relation{
+			From:        s.name,
+			To:          target.name,
+			Kind:        target.kind,
+			Description: description,
+			Protocol:    protocol,
+		})
+	}
+
+	if deps := mappingValue(s.node, "depends_on"); deps != nil {
+		for _, dep := range listOrKeys(deps) {
+			if target, ok := imp.byName[dep.Value]; ok {
+				add(target, "depends_on", "")
+			} else {
+				imp.warn(dep.Line, "service %s depends on unknown service %q", s.name, dep.Value)
+			}
+		}
+	}
+	if links := mappingValue(s.node, "links"); links != nil && links.Kind == yaml.SequenceNode {
+		for _, link := range links.Content {
+			target, _, _ := strings.Cut(link.Value, ":")
+			if t, ok := imp.byName[target]; ok {
+				add(t, "links", "")
+			} else {
+				imp.warn(link.Line, "service %s links unknown service %q", s.name, target)
+			}
+		}
+	}
+	for _, env := range environment(s.node) {
+		for _, match := range urlPattern.FindAllStringSubmatch(env.value, -1) {
+			target := imp.resolve(match[2])
+			if target == nil {
+				continue
+			}
+			add(target, "environment "+env.key, match[1]+"://"+match[2]+match[3])
+		}
+	}
+	return rels
+}
+
+// resolve finds the service reachable under host, by name or network alias.
+func (imp *importer) resolve(host string) *service {
+	if s, ok := imp.byName[host]; ok {
+		return s
+	}
+	for _, s := range imp.services {
+		for _, alias := range s.aliases {
+			if alias == host {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+func (imp *importer) warn(line int, format string, args ...any) {
+	imp.warnings = append(imp.warnings, Warning{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+type envVar struct {
+	key   string
+	value string
+}
+
+// environment reads the list ("KEY=value") and mapping forms in key order.
+func environment(body *yaml.Node) []envVar {
+	node := mappingValue(body, "environment")
+	if node == nil {
+		return nil
+	}
+	vars := make([]envVar, 0)
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			key, value, _ := strings.Cut(item.Value, "=")
+			vars = append(vars, envVar{key: key, value: value})
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			vars = append(vars, envVar{key: node.Content[i].Value, value: node.Content[i+1].Value})
+		}
+	}
+	sort.SliceStable(vars, func(i, j int) bool { return vars[i].key < vars[j].key })
+	return vars
+}
+
+// networkAliases collects aliases declared under networks.<name>.aliases.
+func networkAliases(body *yaml.Node) []string {
+	networks := mappingValue(body, "networks")
+	if networks == nil || networks.Kind != yaml.MappingNode {
+		return nil
+	}
+	aliases := make([]string, 0)
+	for i := 1; i < len(networks.Content); i += 2 {
+		if list := mappingValue(networks.Content[i], "aliases"); list != nil && list.Kind == yaml.SequenceNode {
+			for _, alias := range list.Content {
+				aliases = append(aliases, alias.Value)
+			}
+		}
+	}
+	return aliases
+}
+
+// listOrKeys returns sequence items, or mapping keys for the long syntax.
+func listOrKeys(node *yaml.Node) []*yaml.Node {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return node.Content
+	case yaml.MappingNode:
+		keys := make([]*yaml.Node, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			keys = append(keys, node.Content[i])
+		}
+		return keys
+	}
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
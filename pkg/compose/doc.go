@@ -0,0 +1,6 @@
+// Package compose bootstraps an architecture model from a docker-compose
+// file. Services become containers in one boundary named after the project;
+// well-known database images become databases, and depends_on, links and
+// URLs in environment variables become relations. Anything that cannot be
+// mapped is reported as a warning.
+package compose
@@ -3,10 +3,13 @@ package config
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/PET-dev-projects/ArchLint/pkg/checks"
 	"github.com/PET-dev-projects/ArchLint/pkg/engine"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
 // File describes the YAML configuration file layout.
@@ -14,35 +17,135 @@ type File struct {
 	Rules []RuleEntry `yaml:"rules"`
 }
 
-// RuleEntry declares an individual rule override.
+// RuleEntry declares an individual rule override. Entries with a match
+// block define custom declarative rules instead.
 type RuleEntry struct {
-	ID      string                 `yaml:"id"`
-	Enabled *bool                  `yaml:"enabled"`
-	Config  map[string]any         `yaml:"config"`
-	Meta    map[string]interface{} `yaml:"-"`
+	ID          string                    `yaml:"id"`
+	Enabled     *bool                     `yaml:"enabled"`
+	Config      map[string]any            `yaml:"config"`
+	Description string                    `yaml:"description"`
+	Severity    types.Severity            `yaml:"severity"`
+	Classes     map[string]types.Severity `yaml:"classes"`
+	Message     string                    `yaml:"message"`
+	Match       *checks.Match             `yaml:"match"`
+	Meta        map[string]interface{}    `yaml:"-"`
 }
 
-// LoadOptionsFromFile parses the YAML config file into engine.Options.
+// LoadOptionsFromFile parses the YAML config file into engine.Options. Any
+// problem reported by ValidateFile makes it fail with a *ValidationError.
 func LoadOptionsFromFile(path string) (engine.Options, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return engine.Options{}, err
 	}
-	var file File
-	if err := yaml.Unmarshal(data, &file); err != nil {
-		return engine.Options{}, err
+	opts, problems, err := parse(data)
+	if err != nil {
+		return engine.Options{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(problems) > 0 {
+		return engine.Options{}, &ValidationError{File: path, Problems: problems}
+	}
+	return opts, nil
+}
+
+// ValidateFile reports every problem in the config file at path. The error
+// is reserved for unreadable files and YAML syntax errors.
+func ValidateFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	_, problems, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return problems, nil
+}
+
+func parse(data []byte) (engine.Options, []Problem, error) {
+	file, nodes, problems, err := decode(data)
+	if err != nil {
+		return engine.Options{}, nil, err
 	}
+
 	opts := engine.Options{
 		RuleConfig:   map[string]map[string]any{},
 		EnabledRules: []string{},
+		Severities:   map[string]engine.SeverityOverride{},
 	}
+	builtin := checks.DefaultRegistry()
+	seen := map[string]struct{}{}
 	for idx, entry := range file.Rules {
+		node := nodes.entry(idx)
+		fail := func(at *yaml.Node, format string, args ...any) {
+			problems = append(problems, problemAt(at, fmt.Sprintf("rules[%d]: ", idx)+fmt.Sprintf(format, args...)))
+		}
 		if entry.ID == "" {
-			return engine.Options{}, fmt.Errorf("config rules[%d]: id is required", idx)
+			fail(node, "id is required")
+			continue
+		}
+		if _, ok := seen[entry.ID]; ok {
+			fail(mappingValue(node, "id"), "rule %s is declared more than once", entry.ID)
+			continue
+		}
+		seen[entry.ID] = struct{}{}
+
+		var def *checks.RuleDefinition
+		var meta types.RuleMetadata
+		if entry.Match != nil {
+			parsed, err := customRule(entry, builtin)
+			if err != nil {
+				fail(node, "%s: %v", entry.ID, err)
+				continue
+			}
+			def = &parsed
+		} else {
+			var ok bool
+			meta, ok = builtinMetadata(builtin, entry.ID)
+			if !ok {
+				fail(mappingValue(node, "id"), "unknown rule ID %q (see 'archlint rules list')", entry.ID)
+				continue
+			}
+			configProblems := validateRuleConfig(meta, mappingValue(node, "config"))
+			for _, p := range configProblems {
+				p.Message = fmt.Sprintf("rules[%d]: %s ", idx, entry.ID) + p.Message
+				problems = append(problems, p)
+			}
+			if len(configProblems) > 0 {
+				continue
+			}
+		}
+
+		var override *engine.SeverityOverride
+		if def == nil && (entry.Severity != "" || len(entry.Classes) > 0) {
+			parsed, err := severityOverride(entry, meta)
+			if err != nil {
+				at := mappingValue(node, "classes")
+				if !entry.Severity.Valid() && entry.Severity != "" {
+					at = mappingValue(node, "severity")
+				}
+				fail(at, "%s: %v", entry.ID, err)
+				continue
+			}
+			override = &parsed
+		}
+
+		if entry.ID == engine.SuppressionRuleID {
+			// Suppression checks always run; the entry only tunes severity.
+			if override != nil {
+				opts.Severities[entry.ID] = *override
+			}
+			continue
 		}
 		if entry.Enabled != nil && !*entry.Enabled {
 			continue
 		}
+		if def != nil {
+			opts.CustomRules = append(opts.CustomRules, *def)
+		}
+		if override != nil {
+			opts.Severities[entry.ID] = *override
+		}
 		opts.EnabledRules = append(opts.EnabledRules, entry.ID)
 		if entry.Config != nil {
 			opts.RuleConfig[entry.ID] = entry.Config
@@ -51,8 +154,80 @@ func LoadOptionsFromFile(path string) (engine.Options, error) {
 	if len(opts.RuleConfig) == 0 {
 		opts.RuleConfig = nil
 	}
+	if len(opts.Severities) == 0 {
+		opts.Severities = nil
+	}
 	if len(opts.EnabledRules) == 0 {
 		opts.EnabledRules = nil
 	}
-	return opts, nil
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return opts, problems, nil
+}
+
+func customRule(entry RuleEntry, builtin checks.Registry) (checks.RuleDefinition, error) {
+	if _, ok := builtin.Find(entry.ID); ok {
+		return checks.RuleDefinition{}, fmt.Errorf("custom rule id clashes with built-in rule")
+	}
+	if entry.Config != nil {
+		return checks.RuleDefinition{}, fmt.Errorf("custom rules take no config; use match")
+	}
+	def := checks.RuleDefinition{
+		ID:          entry.ID,
+		Description: entry.Description,
+		Severity:    entry.Severity,
+		Message:     entry.Message,
+		Match:       *entry.Match,
+	}
+	if len(entry.Classes) > 0 {
+		return checks.RuleDefinition{}, fmt.Errorf("custom rules have no message classes")
+	}
+	if def.Severity == "" {
+		def.Severity = types.SeverityError
+	}
+	if err := checks.ValidateDefinition(def); err != nil {
+		return checks.RuleDefinition{}, err
+	}
+	return def, nil
+}
+
+// builtinMetadata describes the built-in rule or engine check with the given ID.
+func builtinMetadata(builtin checks.Registry, id string) (types.RuleMetadata, bool) {
+	if rule, ok := builtin.Find(id); ok {
+		return rule.Metadata(), true
+	}
+	if id == engine.SuppressionRuleID {
+		return engine.SuppressionRuleMetadata(), true
+	}
+	return types.RuleMetadata{}, false
+}
+
+func severityOverride(entry RuleEntry, meta types.RuleMetadata) (engine.SeverityOverride, error) {
+	if entry.Severity != "" && !entry.Severity.Valid() {
+		return engine.SeverityOverride{}, fmt.Errorf("invalid severity %q", entry.Severity)
+	}
+	override := engine.SeverityOverride{Severity: entry.Severity}
+	if len(entry.Classes) == 0 {
+		return override, nil
+	}
+	known := map[string]struct{}{}
+	for _, class := range meta.Classes {
+		known[class] = struct{}{}
+	}
+	classes := make([]string, 0, len(entry.Classes))
+	for class := range entry.Classes {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	override.Classes = map[string]types.Severity{}
+	for _, class := range classes {
+		severity := entry.Classes[class]
+		if _, ok := known[class]; !ok {
+			return engine.SeverityOverride{}, fmt.Errorf("unknown message class %q", class)
+		}
+		if !severity.Valid() {
+			return engine.SeverityOverride{}, fmt.Errorf("class %s: invalid severity %q", class, severity)
+		}
+		override.Classes[class] = severity
+	}
+	return override, nil
 }
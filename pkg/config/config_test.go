@@ -1,8 +1,10 @@
 package config_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/PET-dev-projects/ArchLint/pkg/config"
@@ -34,3 +36,194 @@ rules:
 		t.Fatalf("failed to parse config overrides: %+v", opts.RuleConfig)
 	}
 }
+
+func TestLoadOptionsFromFileCustomRules(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "rules.yaml")
+	content := `
+rules:
+  - id: ARCH-ACL
+  - id: TEAM-FRONTEND-NO-DB
+    severity: warn
+    message: "{{.From}} must not use {{.To}}"
+    match:
+      relation:
+        from: {tags: [frontend]}
+        to: {type: [database]}
+  - id: TEAM-DISABLED
+    enabled: false
+    match:
+      container: {tags: [legacy]}
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp: %v", err)
+	}
+	opts, err := config.LoadOptionsFromFile(file)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(opts.EnabledRules) != 2 || opts.EnabledRules[1] != "TEAM-FRONTEND-NO-DB" {
+		t.Fatalf("unexpected enabled rules: %+v", opts.EnabledRules)
+	}
+	if len(opts.CustomRules) != 1 {
+		t.Fatalf("expected one custom rule, got %+v", opts.CustomRules)
+	}
+	def := opts.CustomRules[0]
+	if def.Severity != "warn" || def.Match.Relation == nil || def.Match.Relation.To.Types[0] != "database" {
+		t.Fatalf("unexpected custom rule: %+v", def)
+	}
+
+	for name, content := range map[string]string{
+		"built-in id": "rules:\n  - id: ARCH-ACL\n    match:\n      container: {tags: [x]}\n",
+		"no selector": "rules:\n  - id: TEAM-X\n    match: {}\n",
+		"bad message": "rules:\n  - id: TEAM-X\n    message: \"{{.From\"\n    match:\n      container: {}\n",
+	} {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatalf("write temp: %v", err)
+		}
+		if _, err := config.LoadOptionsFromFile(file); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoadOptionsFromFileSeverityOverrides(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "rules.yaml")
+	content := `
+rules:
+  - id: ARCH-DB-ISOLATION
+    severity: warn
+    classes:
+      no-inbound: info
+  - id: ARCH-ACL
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp: %v", err)
+	}
+	opts, err := config.LoadOptionsFromFile(file)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	override, ok := opts.Severities["ARCH-DB-ISOLATION"]
+	if !ok || override.Severity != "warn" || override.Classes["no-inbound"] != "info" {
+		t.Fatalf("unexpected overrides: %+v", opts.Severities)
+	}
+	if _, ok := opts.Severities["ARCH-ACL"]; ok {
+		t.Fatalf("ARCH-ACL must not get an override: %+v", opts.Severities)
+	}
+
+	for name, content := range map[string]string{
+		"bad severity": "rules:\n  - id: ARCH-ACL\n    severity: fatal\n",
+		"bad class":    "rules:\n  - id: ARCH-ACL\n    classes:\n      nope: warn\n",
+	} {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatalf("write temp: %v", err)
+		}
+		if _, err := config.LoadOptionsFromFile(file); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "rules.yaml")
+	content := `rules:
+  - id: ARCH-CRUD
+    config:
+      allowedTag: [crud]
+  - id: ARCH-BOUNDARIES
+    config:
+      maxCrossRelations: "five"
+  - id: ARCH-NOPE
+  - id: ARCH-ACL
+    enabld: true
+  - id: ARCH-SUPPRESSION
+    severity: error
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp: %v", err)
+	}
+	problems, err := config.ValidateFile(file)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	want := []struct {
+		line int
+		text string
+	}{
+		{4, `unknown key "allowedTag"`},
+		{7, "expected integer"},
+		{8, `unknown rule ID "ARCH-NOPE"`},
+		{10, "field enabld not found"},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), problems)
+	}
+	for i, w := range want {
+		if problems[i].Line != w.line || !strings.Contains(problems[i].Message, w.text) {
+			t.Fatalf("problem %d: expected line %d with %q, got %v", i, w.line, w.text, problems[i])
+		}
+	}
+
+	_, err = config.LoadOptionsFromFile(file)
+	var verr *config.ValidationError
+	if !errors.As(err, &verr) || len(verr.Problems) != len(want) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	if err := os.WriteFile(file, []byte("rules:\n  - id: ARCH-SUPPRESSION\n    classes:\n      unused: error\n"), 0o600); err != nil {
+		t.Fatalf("write temp: %v", err)
+	}
+	opts, err := config.LoadOptionsFromFile(file)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if opts.EnabledRules != nil || opts.Severities["ARCH-SUPPRESSION"].Classes["unused"] != "error" {
+		t.Fatalf("suppression entry must only tune severity: %+v", opts)
+	}
+}
+
+func TestValidateFileNestedConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rules.yaml")
+	content := `rules:
+  - id: ARCH-LAYERS
+    config:
+      layers:
+        - {name: edge, tag: [edge]}
+        - {name: data, types: database}
+  - id: ARCH-COUPLING
+    config:
+      overrides:
+        - tags: [edge]
+          maxFanOut: many
+  - id: ARCH-BOUNDARIES
+    config:
+      overrides:
+        - {names: [Payments], maxCrossRelations: 3}
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp: %v", err)
+	}
+	problems, err := config.ValidateFile(file)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	want := []struct {
+		line int
+		text string
+	}{
+		{5, `config.layers[0]: unknown key "tag" (known keys: name, tags, types)`},
+		{6, `config.layers[1].types: expected list of string, got "database"`},
+		{11, `config.overrides[0].maxFanOut: expected integer, got "many"`},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), problems)
+	}
+	for i, w := range want {
+		if problems[i].Line != w.line || !strings.Contains(problems[i].Message, w.text) {
+			t.Fatalf("problem %d: expected line %d with %q, got %v", i, w.line, w.text, problems[i])
+		}
+	}
+}
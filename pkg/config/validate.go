@@ -0,0 +1,228 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// Problem is a configuration mistake at a position in the config file.
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// String renders the problem as line:column: message.
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	if p.Column == 0 {
+		return fmt.Sprintf("%d: %s", p.Line, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+// ValidationError lists every problem found in a config file.
+type ValidationError struct {
+	File     string
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		lines = append(lines, e.File+":"+p.String())
+	}
+	return "invalid config:\n" + strings.Join(lines, "\n")
+}
+
+// entryNodes gives access to the YAML nodes of the rules list.
+type entryNodes []*yaml.Node
+
+func (n entryNodes) entry(idx int) *yaml.Node {
+	if idx < len(n) {
+		return n[idx]
+	}
+	return nil
+}
+
+var yamlLinePrefix = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// decode strictly decodes data, turning unknown fields and type mismatches
+// into problems.
+func decode(data []byte) (File, entryNodes, []Problem, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return File{}, nil, nil, err
+	}
+
+	var file File
+	var problems []Problem
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return File{}, nil, nil, err
+		}
+		for _, msg := range typeErr.Errors {
+			p := Problem{Message: msg}
+			if m := yamlLinePrefix.FindStringSubmatch(msg); m != nil {
+				p.Line, _ = strconv.Atoi(m[1])
+				p.Message = m[2]
+			}
+			problems = append(problems, p)
+		}
+	}
+
+	var nodes entryNodes
+	if len(root.Content) > 0 {
+		if rules := mappingValue(root.Content[0], "rules"); rules != nil && rules.Kind == yaml.SequenceNode {
+			nodes = rules.Content
+		}
+	}
+	return file, nodes, problems, nil
+}
+
+// validateRuleConfig checks a rule's config mapping against its schema.
+func validateRuleConfig(meta types.RuleMetadata, node *yaml.Node) []Problem {
+	if node == nil || node.Tag == "!!null" {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return []Problem{problemAt(node, "config: expected a mapping")}
+	}
+	return validateFields(meta.Config, node, "config", "it accepts no config")
+}
+
+// validateFields checks the keys of mapping node against fields; path names
+// the mapping in problem messages.
+func validateFields(schema []types.ConfigField, node *yaml.Node, path, noFields string) []Problem {
+	fields := map[string]types.ConfigField{}
+	known := make([]string, 0, len(schema))
+	for _, field := range schema {
+		fields[field.Name] = field
+		known = append(known, field.Name)
+	}
+	sort.Strings(known)
+
+	var problems []Problem
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field, ok := fields[key.Value]
+		if !ok {
+			hint := noFields
+			if len(known) > 0 {
+				hint = "known keys: " + strings.Join(known, ", ")
+			}
+			problems = append(problems, problemAt(key, fmt.Sprintf("%s: unknown key %q (%s)", path, key.Value, hint)))
+			continue
+		}
+		problems = append(problems, validateValue(field, field.Type, value, path+"."+key.Value)...)
+	}
+	return problems
+}
+
+// validateValue checks that node holds a value of the schema type typ,
+// descending into lists, maps and objects so problems point at the
+// offending element.
+func validateValue(field types.ConfigField, typ string, node *yaml.Node, path string) []Problem {
+	mismatch := func() []Problem {
+		return []Problem{problemAt(node, fmt.Sprintf("%s: expected %s, got %s", path, typ, describeNode(node)))}
+	}
+	if elem, ok := strings.CutPrefix(typ, "list of "); ok {
+		if node.Kind != yaml.SequenceNode {
+			return mismatch()
+		}
+		var problems []Problem
+		for i, item := range node.Content {
+			problems = append(problems, validateValue(field, elem, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return problems
+	}
+	if elem, ok := strings.CutPrefix(typ, "map of "); ok {
+		if node.Kind != yaml.MappingNode {
+			return mismatch()
+		}
+		var problems []Problem
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			problems = append(problems, validateValue(field, elem, node.Content[i+1], path+"."+node.Content[i].Value)...)
+		}
+		return problems
+	}
+	if typ == "object" {
+		if node.Kind != yaml.MappingNode {
+			return mismatch()
+		}
+		return validateFields(field.Fields, node, path, "no keys are known")
+	}
+	if !matchesScalar(node, typ) {
+		return mismatch()
+	}
+	return nil
+}
+
+// matchesScalar reports whether node holds a scalar of the schema type.
+func matchesScalar(node *yaml.Node, typ string) bool {
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch typ {
+	case "boolean":
+		return node.Tag == "!!bool"
+	case "integer":
+		return node.Tag == "!!int"
+	case "number":
+		return node.Tag == "!!int" || node.Tag == "!!float"
+	default:
+		return node.Tag == "!!str"
+	}
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "a mapping"
+	}
+	switch node.Tag {
+	case "!!bool":
+		return "boolean " + node.Value
+	case "!!int", "!!float":
+		return "number " + node.Value
+	case "!!null":
+		return "null"
+	}
+	return strconv.Quote(node.Value)
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func problemAt(node *yaml.Node, message string) Problem {
+	if node == nil {
+		return Problem{Message: message}
+	}
+	return Problem{Line: node.Line, Column: node.Column, Message: message}
+}
@@ -0,0 +1,265 @@
+package diff
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// ChangeKind classifies a semantic difference between two models.
+type ChangeKind string
+
+const (
+	BoundaryAdded    ChangeKind = "boundary-added"
+	BoundaryRemoved  ChangeKind = "boundary-removed"
+	BoundaryMoved    ChangeKind = "boundary-moved"
+	BoundaryChanged  ChangeKind = "boundary-changed"
+	ContainerAdded   ChangeKind = "container-added"
+	ContainerRemoved ChangeKind = "container-removed"
+	ContainerMoved   ChangeKind = "container-moved"
+	ContainerChanged ChangeKind = "container-changed"
+	RelationAdded    ChangeKind = "relation-added"
+	RelationRemoved  ChangeKind = "relation-removed"
+	RelationChanged  ChangeKind = "relation-changed"
+)
+
+// externalsScope names the pseudo-boundary holding top-level externals.
+const externalsScope = "externals"
+
+// Change describes a single difference. Subject is a boundary name, container
+// name, or "from -> to" for relations; Field names the changed attribute for
+// *-changed kinds. Before/After hold the old/new value: the enclosing boundary
+// path for boundaries and containers, the kind for added/removed relations.
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	Subject string     `json:"subject"`
+	Field   string     `json:"field,omitempty"`
+	Before  string     `json:"before,omitempty"`
+	After   string     `json:"after,omitempty"`
+}
+
+// Result lists all changes in deterministic order.
+type Result struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty reports whether the models are semantically identical.
+func (r Result) Empty() bool { return len(r.Changes) == 0 }
+
+// Compare matches boundaries, containers and relations by name (not list
+// index) and reports what changed from prev to next.
+func Compare(prev, next *model.Architecture) Result {
+	changes := make([]Change, 0)
+	changes = append(changes, compareBoundaries(prev, next)...)
+	changes = append(changes, compareContainers(prev, next)...)
+	changes = append(changes, compareRelations(prev, next)...)
+	return Result{Changes: changes}
+}
+
+type boundaryInfo struct {
+	boundary *model.Boundary
+	parent   string
+}
+
+func indexBoundaries(a *model.Architecture) (map[string]boundaryInfo, map[*model.Boundary]string) {
+	byName := map[string]boundaryInfo{}
+	paths := map[*model.Boundary]string{}
+	var walk func(b *model.Boundary, parent string)
+	walk = func(b *model.Boundary, parent string) {
+		path := b.Name
+		if parent != "" {
+			path = parent + " / " + b.Name
+		}
+		paths[b] = path
+		if _, dup := byName[b.Name]; !dup {
+			byName[b.Name] = boundaryInfo{boundary: b, parent: parent}
+		}
+		for i := range b.Boundaries {
+			walk(&b.Boundaries[i], path)
+		}
+	}
+	for i := range a.Boundaries {
+		walk(&a.Boundaries[i], "")
+	}
+	return byName, paths
+}
+
+func compareBoundaries(prev, next *model.Architecture) []Change {
+	oldIdx, _ := indexBoundaries(prev)
+	newIdx, _ := indexBoundaries(next)
+
+	changes := make([]Change, 0)
+	for _, name := range unionKeys(oldIdx, newIdx) {
+		before, inOld := oldIdx[name]
+		after, inNew := newIdx[name]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: BoundaryAdded, Subject: name, After: after.parent})
+		case !inNew:
+			changes = append(changes, Change{Kind: BoundaryRemoved, Subject: name, Before: before.parent})
+		default:
+			if before.parent != after.parent {
+				changes = append(changes, Change{Kind: BoundaryMoved, Subject: name, Before: before.parent, After: after.parent})
+			}
+			changes = appendField(changes, BoundaryChanged, name, "tags", joinSorted(before.boundary.Tags), joinSorted(after.boundary.Tags))
+			changes = appendField(changes, BoundaryChanged, name, "owner", before.boundary.Owner, after.boundary.Owner)
+		}
+	}
+	return changes
+}
+
+type containerInfo struct {
+	container *model.Container
+	scope     string
+}
+
+func indexContainers(a *model.Architecture) map[string]containerInfo {
+	_, paths := indexBoundaries(a)
+	idx := map[string]containerInfo{}
+	for _, ref := range a.Containers() {
+		name := ref.Container.Name
+		if name == "" {
+			continue
+		}
+		if _, dup := idx[name]; dup {
+			continue
+		}
+		scope := externalsScope
+		if ref.Boundary != nil {
+			scope = paths[ref.Boundary]
+		}
+		idx[name] = containerInfo{container: ref.Container, scope: scope}
+	}
+	return idx
+}
+
+func compareContainers(prev, next *model.Architecture) []Change {
+	oldIdx := indexContainers(prev)
+	newIdx := indexContainers(next)
+
+	changes := make([]Change, 0)
+	for _, name := range unionKeys(oldIdx, newIdx) {
+		before, inOld := oldIdx[name]
+		after, inNew := newIdx[name]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: ContainerAdded, Subject: name, After: after.scope})
+		case !inNew:
+			changes = append(changes, Change{Kind: ContainerRemoved, Subject: name, Before: before.scope})
+		default:
+			if before.scope != after.scope {
+				changes = append(changes, Change{Kind: ContainerMoved, Subject: name, Before: before.scope, After: after.scope})
+			}
+			b, a := before.container, after.container
+			changes = appendField(changes, ContainerChanged, name, "type", string(b.Type), string(a.Type))
+			changes = appendField(changes, ContainerChanged, name, "technology", b.Technology, a.Technology)
+			changes = appendField(changes, ContainerChanged, name, "tags", joinSorted(b.Tags), joinSorted(a.Tags))
+			changes = appendField(changes, ContainerChanged, name, "provides", interfaceNames(b.Provides), interfaceNames(a.Provides))
+		}
+	}
+	return changes
+}
+
+// relationKey identifies a relation by its endpoints; parallel relations
+// between the same pair are told apart by their position among that pair.
+type relationKey struct {
+	from, to string
+	seq      int
+}
+
+func (k relationKey) subject() string {
+	s := k.from + " -> " + k.to
+	if k.seq > 0 {
+		s += " #" + strconv.Itoa(k.seq+1)
+	}
+	return s
+}
+
+func indexRelations(a *model.Architecture) (map[relationKey]*model.Relation, []relationKey) {
+	idx := map[relationKey]*model.Relation{}
+	keys := make([]relationKey, 0)
+	seen := map[[2]string]int{}
+	for _, ref := range a.Relations() {
+		pair := [2]string{ref.Relation.From, ref.Relation.To}
+		key := relationKey{from: pair[0], to: pair[1], seq: seen[pair]}
+		seen[pair]++
+		idx[key] = ref.Relation
+		keys = append(keys, key)
+	}
+	return idx, keys
+}
+
+func compareRelations(prev, next *model.Architecture) []Change {
+	oldIdx, oldKeys := indexRelations(prev)
+	newIdx, newKeys := indexRelations(next)
+
+	keys := append(append([]relationKey{}, oldKeys...), newKeys...)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].from != keys[j].from {
+			return keys[i].from < keys[j].from
+		}
+		if keys[i].to != keys[j].to {
+			return keys[i].to < keys[j].to
+		}
+		return keys[i].seq < keys[j].seq
+	})
+
+	changes := make([]Change, 0)
+	for i, key := range keys {
+		if i > 0 && keys[i-1] == key {
+			continue
+		}
+		before, inOld := oldIdx[key]
+		after, inNew := newIdx[key]
+		subject := key.subject()
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: RelationAdded, Subject: subject, After: string(after.Kind)})
+		case !inNew:
+			changes = append(changes, Change{Kind: RelationRemoved, Subject: subject, Before: string(before.Kind)})
+		default:
+			changes = appendField(changes, RelationChanged, subject, "kind", string(before.Kind), string(after.Kind))
+			changes = appendField(changes, RelationChanged, subject, "protocol", before.Protocol, after.Protocol)
+			changes = appendField(changes, RelationChanged, subject, "uses", before.Uses, after.Uses)
+			changes = appendField(changes, RelationChanged, subject, "tags", joinSorted(before.Tags), joinSorted(after.Tags))
+		}
+	}
+	return changes
+}
+
+func appendField(changes []Change, kind ChangeKind, subject, field, before, after string) []Change {
+	if before == after {
+		return changes
+	}
+	return append(changes, Change{Kind: kind, Subject: subject, Field: field, Before: before, After: after})
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinSorted(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+func interfaceNames(provides []model.Interface) string {
+	names := make([]string, 0, len(provides))
+	for _, iface := range provides {
+		names = append(names, iface.Name)
+	}
+	return joinSorted(names)
+}
@@ -0,0 +1,65 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/diff"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+func TestCompare(t *testing.T) {
+	prev := &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{{
+			Name: "Core",
+			Containers: []model.Container{
+				{Name: "api", Type: model.ContainerService, Tags: []string{"acl"}},
+				{Name: "repo", Type: model.ContainerService},
+				{Name: "db", Type: model.ContainerDatabase},
+			},
+			Boundaries: []model.Boundary{{Name: "Billing"}},
+			Relations: []model.Relation{
+				{From: "api", To: "repo", Kind: model.RelationKindSync},
+				{From: "repo", To: "db", Kind: model.RelationKindDB},
+			},
+		}},
+	}
+	next := &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{{
+			Name: "Core",
+			Containers: []model.Container{
+				{Name: "repo", Type: model.ContainerService},
+				{Name: "api", Type: model.ContainerService, Tags: []string{"acl", "edge"}},
+			},
+			Relations: []model.Relation{
+				{From: "repo", To: "db", Kind: model.RelationKindDB},
+				{From: "api", To: "repo", Kind: model.RelationKindAsync, Protocol: "kafka://orders"},
+			},
+		}, {
+			Name:       "Billing",
+			Containers: []model.Container{{Name: "db", Type: model.ContainerDatabase}},
+		}},
+	}
+
+	got := diff.Compare(prev, next).Changes
+	want := []diff.Change{
+		{Kind: diff.BoundaryMoved, Subject: "Billing", Before: "Core", After: ""},
+		{Kind: diff.ContainerChanged, Subject: "api", Field: "tags", Before: "acl", After: "acl, edge"},
+		{Kind: diff.ContainerMoved, Subject: "db", Before: "Core", After: "Billing"},
+		{Kind: diff.RelationChanged, Subject: "api -> repo", Field: "kind", Before: "sync", After: "async"},
+		{Kind: diff.RelationChanged, Subject: "api -> repo", Field: "protocol", Before: "", After: "kafka://orders"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("change %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	if res := diff.Compare(next, next); !res.Empty() {
+		t.Fatalf("expected no changes comparing a model with itself, got %+v", res.Changes)
+	}
+}
@@ -0,0 +1,4 @@
+// Package diff compares two architecture models semantically. Boundaries and
+// containers are matched by name and relations by their endpoints, so
+// reordering YAML lists does not show up as a change.
+package diff
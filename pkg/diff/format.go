@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText renders changes as a plain-text list.
+func WriteText(w io.Writer, r Result) error {
+	if r.Empty() {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+	for _, c := range r.Changes {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", c.Kind, c.Subject, describe(c)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON serializes the diff result to JSON.
+func WriteJSON(w io.Writer, r Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteMarkdown renders changes as a Markdown table suitable for PR comments.
+func WriteMarkdown(w io.Writer, r Result) error {
+	if r.Empty() {
+		_, err := fmt.Fprintln(w, "_No architecture changes._")
+		return err
+	}
+	lines := []string{
+		"| Change | Subject | Details |",
+		"|--------|---------|---------|",
+	}
+	for _, c := range r.Changes {
+		lines = append(lines, fmt.Sprintf("| %s | `%s` | %s |", c.Kind, escapeMarkdown(c.Subject), escapeMarkdown(describe(c))))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func describe(c Change) string {
+	switch {
+	case c.Field != "":
+		return fmt.Sprintf("%s: %s -> %s", c.Field, orNone(c.Before), orNone(c.After))
+	case c.Before != "" && c.After != "":
+		return fmt.Sprintf("%s -> %s", c.Before, c.After)
+	case c.After != "":
+		return c.After
+	case c.Before != "":
+		return c.Before
+	default:
+		return ""
+	}
+}
+
+func orNone(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+func escapeMarkdown(v string) string {
+	return strings.ReplaceAll(v, "|", "\\|")
+}
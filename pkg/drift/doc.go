@@ -0,0 +1,5 @@
+// Package drift compares an architecture model with call edges observed at
+// runtime, read from an OpenTelemetry trace export or a simple CSV file. It
+// reports undeclared calls, declared relations that never showed up and
+// protocol mismatches as ARCH-DRIFT findings.
+package drift
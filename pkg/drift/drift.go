@@ -0,0 +1,244 @@
+package drift
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// RuleID identifies drift findings.
+const RuleID = "ARCH-DRIFT"
+
+// Message classes. Drift findings keep the severities below; the check
+// config file does not apply to them.
+const (
+	ClassUndeclared       = "undeclared"
+	ClassUnobserved       = "unobserved"
+	ClassProtocolMismatch = "protocol-mismatch"
+	ClassUnknownService   = "unknown-service"
+)
+
+// RuleMetadata describes drift findings.
+func RuleMetadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              RuleID,
+		Description:     "Observed calls match the declared relations.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "A model that disagrees with production traffic stops being a source of truth; drift shows where the diagram or the system needs fixing.",
+		DocURL:          types.RuleDocURL(RuleID),
+		Classes:         []string{ClassUndeclared, ClassUnobserved, ClassProtocolMismatch, ClassUnknownService},
+		ClassSeverities: map[string]types.Severity{
+			ClassUnobserved:       types.SeverityWarn,
+			ClassProtocolMismatch: types.SeverityWarn,
+			ClassUnknownService:   types.SeverityInfo,
+		},
+	}
+}
+
+// Mapping translates telemetry service names into container names. Keys of
+// Names may be path.Match patterns; exact keys win over patterns. Names
+// without a mapping are used as container names as-is. Ignored names
+// (patterns allowed) are dropped.
+type Mapping struct {
+	Names  map[string]string `yaml:"names"`
+	Ignore []string          `yaml:"ignore"`
+}
+
+func (m Mapping) resolve(name string) (string, bool) {
+	for _, pattern := range m.Ignore {
+		if ok, _ := path.Match(pattern, name); ok {
+			return "", false
+		}
+	}
+	if container, ok := m.Names[name]; ok {
+		return container, true
+	}
+	patterns := make([]string, 0, len(m.Names))
+	for pattern := range m.Names {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return m.Names[pattern], true
+		}
+	}
+	return name, true
+}
+
+type observation struct {
+	protocols map[string]struct{}
+	sources   map[string]struct{}
+	count     int
+}
+
+// Compare reports the differences between the relations declared in m and
+// the observed edges. Declared relations are only reported as unobserved
+// when their caller appears in the telemetry at all, so uninstrumented
+// containers do not produce noise.
+func Compare(m *model.Architecture, edges []Edge, mapping Mapping) []types.Finding {
+	containers := m.ContainerMap()
+	findings := make([]types.Finding, 0)
+
+	unknown := map[string]struct{}{}
+	observed := map[[2]string]*observation{}
+	callers := map[string]struct{}{}
+	for _, e := range edges {
+		from, okFrom := mapping.resolve(e.Caller)
+		to, okTo := mapping.resolve(e.Callee)
+		if !okFrom || !okTo {
+			continue
+		}
+		known := true
+		for _, name := range []string{from, to} {
+			if _, ok := containers[name]; !ok {
+				unknown[name] = struct{}{}
+				known = false
+			}
+		}
+		if !known || from == to {
+			continue
+		}
+		callers[from] = struct{}{}
+		key := [2]string{from, to}
+		obs, ok := observed[key]
+		if !ok {
+			obs = &observation{protocols: map[string]struct{}{}, sources: map[string]struct{}{}}
+			observed[key] = obs
+		}
+		if e.Protocol != "" {
+			obs.protocols[e.Protocol] = struct{}{}
+		}
+		obs.sources[e.Caller+" -> "+e.Callee] = struct{}{}
+		obs.count += max(e.Count, 1)
+	}
+
+	for _, name := range sortedSet(unknown) {
+		findings = append(findings, types.Finding{
+			RuleID:   RuleID,
+			Severity: types.SeverityInfo,
+			Message:  fmt.Sprintf("observed service %s matches no container; map or ignore it", name),
+			Class:    ClassUnknownService,
+			Path:     "telemetry." + name,
+			Meta:     map[string]any{"service": name},
+		})
+	}
+
+	declared := map[[2]string]bool{}
+	for _, relRef := range m.Relations() {
+		declared[[2]string{relRef.Relation.From, relRef.Relation.To}] = true
+	}
+
+	keys := make([][2]string, 0, len(observed))
+	for key := range observed {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		if declared[key] {
+			continue
+		}
+		obs := observed[key]
+		caller := containers[key[0]]
+		findings = append(findings, types.Finding{
+			RuleID:   RuleID,
+			Severity: types.SeverityError,
+			Message:  fmt.Sprintf("observed call from %s to %s is not declared", key[0], key[1]),
+			Class:    ClassUndeclared,
+			Path:     caller.Path,
+			Location: caller.Location(),
+			Meta: map[string]any{
+				"from":      key[0],
+				"to":        key[1],
+				"protocols": sortedSet(obs.protocols),
+				"observed":  sortedSet(obs.sources),
+				"count":     obs.count,
+			},
+		})
+	}
+
+	for _, relRef := range m.Relations() {
+		rel := relRef.Relation
+		key := [2]string{rel.From, rel.To}
+		obs, ok := observed[key]
+		if !ok {
+			if _, instrumented := callers[rel.From]; instrumented {
+				findings = append(findings, types.Finding{
+					RuleID:   RuleID,
+					Severity: types.SeverityWarn,
+					Message:  fmt.Sprintf("declared relation from %s to %s was never observed", rel.From, rel.To),
+					Class:    ClassUnobserved,
+					Path:     relRef.Path,
+					Location: relRef.Location(),
+					Meta:     map[string]any{"from": rel.From, "to": rel.To},
+				})
+			}
+			continue
+		}
+		if rel.Protocol == "" || len(obs.protocols) == 0 {
+			continue
+		}
+		if !protocolObserved(rel.Protocol, obs.protocols) {
+			findings = append(findings, types.Finding{
+				RuleID:   RuleID,
+				Severity: types.SeverityWarn,
+				Message: fmt.Sprintf("relation from %s to %s declares protocol %s but %s was observed",
+					rel.From, rel.To, rel.Protocol, strings.Join(sortedSet(obs.protocols), ", ")),
+				Class:    ClassProtocolMismatch,
+				Path:     relRef.Path,
+				Location: relRef.Location(),
+				Meta: map[string]any{
+					"declared": rel.Protocol,
+					"observed": sortedSet(obs.protocols),
+				},
+			})
+		}
+	}
+	return findings
+}
+
+// protocolObserved compares normalised schemes, e.g. a declared
+// "https://gateway.example/billing" matches an observed "http".
+func protocolObserved(declared string, observed map[string]struct{}) bool {
+	want := normalizeProtocol(declared)
+	for protocol := range observed {
+		if normalizeProtocol(protocol) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeProtocol(protocol string) string {
+	protocol = strings.ToLower(strings.TrimSpace(protocol))
+	if scheme, _, ok := strings.Cut(protocol, "://"); ok {
+		protocol = scheme
+	}
+	switch protocol {
+	case "https", "http2", "h2":
+		return "http"
+	case "grpcs":
+		return "grpc"
+	case "postgresql":
+		return "postgres"
+	}
+	return protocol
+}
+
+func sortedSet(set map[string]struct{}) []string {
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
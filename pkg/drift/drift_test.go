@@ -0,0 +1,100 @@
+package drift_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/drift"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+func loadPayments(t *testing.T) *model.Architecture {
+	t.Helper()
+	arch, err := model.LoadModelFromFile(filepath.Join("..", "..", "examples", "payments.yaml"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	return arch
+}
+
+func TestCompare(t *testing.T) {
+	edges, err := drift.LoadCSV(strings.NewReader(`caller,callee,protocol
+payments-api-7f9c,payments-repo,http
+payments-api-7f9c,antifraud,grpc
+payments-api-7f9c,payments-db,postgresql
+payments-repo,payments-api-7f9c,http
+payments-repo,ledger
+# synthetic traffic
+loadgen-1,payments-api-7f9c,http
+payments-api-7f9c,payments-repo,http
+`))
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	if len(edges) != 6 || edges[3].Count != 2 {
+		t.Fatalf("expected de-duplicated edges with counts, got %+v", edges)
+	}
+
+	mapping, err := drift.LoadMapping(strings.NewReader("names:\n  payments-api-*: payments-api\nignore: [loadgen-*]\n"))
+	if err != nil {
+		t.Fatalf("load mapping: %v", err)
+	}
+
+	findings := drift.Compare(loadPayments(t), edges, mapping)
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Class+"@"+f.Path)
+	}
+	want := []string{
+		"unknown-service@telemetry.ledger",
+		"undeclared@boundaries[0].containers[0]",
+		"undeclared@boundaries[0].containers[1]",
+		"unobserved@boundaries[0].relations[1]",
+		"protocol-mismatch@boundaries[0].relations[2]",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("unexpected findings:\n got %v\nwant %v", got, want)
+	}
+	if findings[1].Meta["to"] != "payments-db" || findings[1].Meta["count"] != 1 {
+		t.Fatalf("unexpected undeclared meta: %v", findings[1].Meta)
+	}
+	meta := drift.RuleMetadata()
+	for _, f := range findings {
+		want, ok := meta.ClassSeverities[f.Class]
+		if !ok {
+			want = meta.DefaultSeverity
+		}
+		if f.Severity != want {
+			t.Fatalf("%s finding has severity %s, metadata lists %s", f.Class, f.Severity, want)
+		}
+	}
+}
+
+func TestLoadOTLP(t *testing.T) {
+	src := `{"resourceSpans":[
+  {"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"payments-api"}}]},
+   "scopeSpans":[{"spans":[
+     {"traceId":"t1","spanId":"a1","kind":2},
+     {"traceId":"t1","spanId":"a2","parentSpanId":"a1","kind":"SPAN_KIND_CLIENT","attributes":[{"key":"http.request.method","value":{"stringValue":"POST"}}]},
+     {"traceId":"t1","spanId":"a3","parentSpanId":"a1","kind":3,"attributes":[
+       {"key":"peer.service","value":{"stringValue":"antifraud"}},
+       {"key":"rpc.system","value":{"stringValue":"grpc"}}]}
+   ]}]}
+]}
+{"resourceSpans":[
+  {"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"payments-repo"}}]},
+   "scopeSpans":[{"spans":[{"traceId":"t1","spanId":"r1","parentSpanId":"a2","kind":2}]}]}
+]}`
+	edges, err := drift.LoadOTLP(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	var got []string
+	for _, e := range edges {
+		got = append(got, e.Caller+">"+e.Callee+":"+e.Protocol)
+	}
+	if strings.Join(got, " ") != "payments-api>antifraud:grpc payments-api>payments-repo:http" {
+		t.Fatalf("unexpected edges: %v", got)
+	}
+}
@@ -0,0 +1,244 @@
+package drift
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Edge is one observed caller -> callee dependency between telemetry
+// service names.
+type Edge struct {
+	Caller   string `json:"caller"`
+	Callee   string `json:"callee"`
+	Protocol string `json:"protocol,omitempty"`
+	// Count is how often the edge was observed.
+	Count int `json:"count"`
+}
+
+// LoadCSV reads "caller,callee[,protocol]" rows. A first row starting with
+// "caller" is treated as a header; blank lines and lines starting with # are
+// skipped.
+func LoadCSV(r io.Reader) ([]Edge, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	edges := newEdgeSet()
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "caller") {
+			continue
+		}
+		if len(record) < 2 || strings.TrimSpace(record[0]) == "" || strings.TrimSpace(record[1]) == "" {
+			row, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: expected caller,callee[,protocol]", row)
+		}
+		protocol := ""
+		if len(record) > 2 {
+			protocol = strings.TrimSpace(record[2])
+		}
+		edges.add(strings.TrimSpace(record[0]), strings.TrimSpace(record[1]), protocol)
+	}
+	return edges.list(), nil
+}
+
+// otlpExport is the subset of an OTLP/JSON ExportTraceServiceRequest used to
+// derive edges, as written by the collector's file exporter.
+type otlpExport struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []otlpAttribute `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []otlpSpan `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type otlpSpan struct {
+	TraceID      string          `json:"traceId"`
+	SpanID       string          `json:"spanId"`
+	ParentSpanID string          `json:"parentSpanId"`
+	Kind         json.RawMessage `json:"kind"`
+	Attributes   []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue *string `json:"stringValue"`
+	} `json:"value"`
+}
+
+// Span kinds as defined by OTLP.
+const (
+	spanKindServer   = 2
+	spanKindClient   = 3
+	spanKindProducer = 4
+	spanKindConsumer = 5
+)
+
+var spanKindNames = map[string]int{
+	"SPAN_KIND_SERVER":   spanKindServer,
+	"SPAN_KIND_CLIENT":   spanKindClient,
+	"SPAN_KIND_PRODUCER": spanKindProducer,
+	"SPAN_KIND_CONSUMER": spanKindConsumer,
+}
+
+type serviceSpan struct {
+	service string
+	span    otlpSpan
+}
+
+// LoadOTLP reads OTLP/JSON trace exports; several exports may follow each
+// other, as in the collector's JSON-lines file output. An edge is recorded
+// when a span's parent belongs to another service, and for client or
+// producer spans naming their target in peer.service.
+func LoadOTLP(r io.Reader) ([]Edge, error) {
+	spans := map[[2]string]serviceSpan{}
+	ordered := make([]serviceSpan, 0)
+	dec := json.NewDecoder(r)
+	for {
+		var export otlpExport
+		if err := dec.Decode(&export); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		for _, rs := range export.ResourceSpans {
+			service := attribute(rs.Resource.Attributes, "service.name")
+			if service == "" {
+				continue
+			}
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					s := serviceSpan{service: service, span: span}
+					spans[[2]string{span.TraceID, span.SpanID}] = s
+					ordered = append(ordered, s)
+				}
+			}
+		}
+	}
+
+	edges := newEdgeSet()
+	for _, s := range ordered {
+		kind := spanKind(s.span.Kind)
+		if peer := attribute(s.span.Attributes, "peer.service"); peer != "" && (kind == spanKindClient || kind == spanKindProducer) {
+			edges.add(s.service, peer, spanProtocol(s.span.Attributes))
+		}
+		if s.span.ParentSpanID == "" {
+			continue
+		}
+		parent, ok := spans[[2]string{s.span.TraceID, s.span.ParentSpanID}]
+		if !ok || parent.service == s.service {
+			continue
+		}
+		protocol := spanProtocol(s.span.Attributes)
+		if protocol == "" {
+			protocol = spanProtocol(parent.span.Attributes)
+		}
+		edges.add(parent.service, s.service, protocol)
+	}
+	return edges.list(), nil
+}
+
+func attribute(attrs []otlpAttribute, key string) string {
+	for _, a := range attrs {
+		if a.Key == key && a.Value.StringValue != nil {
+			return *a.Value.StringValue
+		}
+	}
+	return ""
+}
+
+func spanKind(raw json.RawMessage) int {
+	if len(raw) == 0 {
+		return 0
+	}
+	if n, err := strconv.Atoi(string(raw)); err == nil {
+		return n
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return spanKindNames[name]
+	}
+	return 0
+}
+
+// spanProtocol derives a protocol from semantic-convention attributes.
+func spanProtocol(attrs []otlpAttribute) string {
+	for _, key := range []string{"rpc.system", "messaging.system", "db.system"} {
+		if value := attribute(attrs, key); value != "" {
+			return value
+		}
+	}
+	for _, key := range []string{"http.request.method", "http.method", "url.scheme"} {
+		if attribute(attrs, key) != "" {
+			return "http"
+		}
+	}
+	return ""
+}
+
+type edgeSet struct {
+	edges map[[3]string]*Edge
+}
+
+func newEdgeSet() *edgeSet { return &edgeSet{edges: map[[3]string]*Edge{}} }
+
+func (s *edgeSet) add(caller, callee, protocol string) {
+	key := [3]string{caller, callee, protocol}
+	if e, ok := s.edges[key]; ok {
+		e.Count++
+		return
+	}
+	s.edges[key] = &Edge{Caller: caller, Callee: callee, Protocol: protocol, Count: 1}
+}
+
+func (s *edgeSet) list() []Edge {
+	edges := make([]Edge, 0, len(s.edges))
+	for _, e := range s.edges {
+		edges = append(edges, *e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Caller != edges[j].Caller {
+			return edges[i].Caller < edges[j].Caller
+		}
+		if edges[i].Callee != edges[j].Callee {
+			return edges[i].Callee < edges[j].Callee
+		}
+		return edges[i].Protocol < edges[j].Protocol
+	})
+	return edges
+}
+
+// LoadMapping reads a YAML name mapping:
+//
+//	names:
+//	  checkout-svc: checkout
+//	  "payments-*": payments
+//	ignore: [otel-collector, "loadgen-*"]
+func LoadMapping(r io.Reader) (Mapping, error) {
+	var m Mapping
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return Mapping{}, err
+	}
+	return m, nil
+}
@@ -1,7 +1,9 @@
 package engine
 
 import (
+	"fmt"
 	"sort"
+	"time"
 
 	"github.com/PET-dev-projects/ArchLint/pkg/checks"
 	"github.com/PET-dev-projects/ArchLint/pkg/model"
@@ -12,30 +14,84 @@ import (
 type Options struct {
 	EnabledRules []string
 	RuleConfig   map[string]map[string]any
+	// CustomRules are declarative rules run alongside the built-ins. When
+	// EnabledRules is set they must be listed there as well.
+	CustomRules []checks.RuleDefinition
+	// Severities replaces the severity of findings, keyed by rule ID.
+	Severities map[string]SeverityOverride
+	// Now decides whether inline suppressions have expired; zero means the
+	// current time.
+	Now time.Time
+}
+
+// SeverityOverride changes the severity a rule reports with. A matching
+// entry in Classes (keyed by types.Finding.Class) wins over Severity.
+type SeverityOverride struct {
+	Severity types.Severity
+	Classes  map[string]types.Severity
+}
+
+// RuleMetadata describes every built-in rule known to the engine.
+func RuleMetadata() []types.RuleMetadata {
+	return Options{}.RuleMetadata()
+}
+
+// Registry returns the built-in rules plus the custom rules of o.
+func (o Options) Registry() checks.Registry {
+	registry := checks.DefaultRegistry()
+	for _, def := range o.CustomRules {
+		registry = registry.With(checks.NewDeclarativeRule(def))
+	}
+	return registry
+}
+
+// RuleMetadata describes every rule that RunAll may report for o, including
+// custom rules and inline suppression checks.
+func (o Options) RuleMetadata() []types.RuleMetadata {
+	return append(o.Registry().Metadata(), SuppressionRuleMetadata())
 }
 
 // RunAll executes all enabled rules against the provided model.
 func RunAll(m *model.Architecture, opts Options) []types.Finding {
-	registry := checks.DefaultRegistry()
+	registry := opts.Registry()
+	findings := make([]types.Finding, 0)
 	var rules []checks.Rule
 	if len(opts.EnabledRules) > 0 {
-		for _, id := range opts.EnabledRules {
-			if rule, ok := registry.Find(id); ok {
-				rules = append(rules, rule)
+		for idx, id := range opts.EnabledRules {
+			rule, ok := registry.Find(id)
+			if !ok {
+				findings = append(findings, unknownRuleFinding(idx, id))
+				continue
 			}
+			rules = append(rules, rule)
 		}
 	} else {
 		rules = registry.Rules()
 	}
 
-	findings := make([]types.Finding, 0)
+	ran := map[string]bool{}
 	for _, rule := range rules {
+		ran[rule.ID()] = true
 		cfg := map[string]any(nil)
 		if opts.RuleConfig != nil {
 			cfg = opts.RuleConfig[rule.ID()]
 		}
-		findings = append(findings, rule.Run(m, cfg)...)
+		ruleFindings := rule.Run(m, cfg)
+		if override, ok := opts.Severities[rule.ID()]; ok {
+			override.apply(ruleFindings)
+		}
+		findings = append(findings, ruleFindings...)
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
 	}
+	problems := applySuppressions(m, findings, ran, now)
+	if override, ok := opts.Severities[SuppressionRuleID]; ok {
+		override.apply(problems)
+	}
+	findings = append(findings, problems...)
 
 	sort.SliceStable(findings, func(i, j int) bool {
 		if findings[i].RuleID == findings[j].RuleID {
@@ -49,3 +105,25 @@ func RunAll(m *model.Architecture, opts Options) []types.Finding {
 
 	return findings
 }
+
+func (o SeverityOverride) apply(findings []types.Finding) {
+	for i := range findings {
+		if severity, ok := o.Classes[findings[i].Class]; ok && findings[i].Class != "" {
+			findings[i].Severity = severity
+			continue
+		}
+		if o.Severity != "" && findings[i].Class != checks.ClassConfig {
+			findings[i].Severity = o.Severity
+		}
+	}
+}
+
+func unknownRuleFinding(idx int, id string) types.Finding {
+	return types.Finding{
+		RuleID:   id,
+		Severity: types.SeverityError,
+		Message:  "unknown rule ID in enabled rules",
+		Path:     fmt.Sprintf("options.enabledRules[%d]", idx),
+		Class:    checks.ClassConfig,
+	}
+}
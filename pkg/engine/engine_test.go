@@ -4,9 +4,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/PET-dev-projects/ArchLint/pkg/checks"
 	"github.com/PET-dev-projects/ArchLint/pkg/engine"
 	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
 func TestRunAllDefaultRules(t *testing.T) {
@@ -30,6 +33,22 @@ func TestRunAllEnabledRules(t *testing.T) {
 	}
 }
 
+func TestRunAllUnknownRules(t *testing.T) {
+	arch := loadArch(t, "arch_valid.yaml")
+	findings := engine.RunAll(arch, engine.Options{EnabledRules: []string{"ARCH-ACL", "ARCH-TYPO"}})
+	if len(findings) != 1 || findings[0].RuleID != "ARCH-TYPO" || findings[0].Path != "options.enabledRules[1]" {
+		t.Fatalf("expected unknown rule finding, got %v", findings)
+	}
+
+	findings = engine.RunAll(arch, engine.Options{
+		EnabledRules: []string{"ARCH-CRUD"},
+		RuleConfig:   map[string]map[string]any{"ARCH-CRUD": {"allowedTag": []string{"crud"}}},
+	})
+	if len(findings) != 1 || findings[0].Class != "config" {
+		t.Fatalf("expected unknown config key to be rejected, got %v", findings)
+	}
+}
+
 func TestRunAllRuleConfig(t *testing.T) {
 	arch := loadArch(t, "arch_boundary_weak.yaml")
 	opts := engine.Options{
@@ -46,6 +65,153 @@ func TestRunAllRuleConfig(t *testing.T) {
 	}
 }
 
+func TestRunAllCustomRules(t *testing.T) {
+	arch := loadArch(t, "arch_valid.yaml")
+	custom := checks.RuleDefinition{
+		ID:       "TEAM-NO-EXTERNAL",
+		Severity: types.SeverityWarn,
+		Match: checks.Match{Relation: &checks.RelationSelector{
+			To: &checks.ContainerSelector{Types: []model.ContainerType{model.ContainerExternal}},
+		}},
+	}
+	findings := engine.RunAll(arch, engine.Options{CustomRules: []checks.RuleDefinition{custom}})
+	if len(findings) != 1 || findings[0].RuleID != "TEAM-NO-EXTERNAL" {
+		t.Fatalf("expected custom finding, got %v", findings)
+	}
+
+	findings = engine.RunAll(arch, engine.Options{EnabledRules: []string{"ARCH-ACL"}, CustomRules: []checks.RuleDefinition{custom}})
+	if len(findings) != 0 {
+		t.Fatalf("custom rule not listed in EnabledRules must not run, got %v", findings)
+	}
+}
+
+func TestRunAllSeverityOverrides(t *testing.T) {
+	arch := loadArch(t, "arch_db_isolation.yaml")
+	opts := engine.Options{
+		EnabledRules: []string{"ARCH-DB-ISOLATION"},
+		Severities: map[string]engine.SeverityOverride{
+			"ARCH-DB-ISOLATION": {
+				Severity: types.SeverityWarn,
+				Classes:  map[string]types.Severity{"no-inbound": types.SeverityInfo},
+			},
+		},
+	}
+	findings := engine.RunAll(arch, opts)
+	if len(findings) != 2 {
+		t.Fatalf("expected two findings, got %v", findings)
+	}
+	for _, f := range findings {
+		want := types.SeverityWarn
+		if f.Class == "no-inbound" {
+			want = types.SeverityInfo
+		}
+		if f.Severity != want {
+			t.Fatalf("finding %q: expected %s, got %s", f.Message, want, f.Severity)
+		}
+	}
+
+	opts.RuleConfig = map[string]map[string]any{"ARCH-DB-ISOLATION": {"requireInbound": "yes"}}
+	findings = engine.RunAll(arch, opts)
+	if len(findings) != 1 || findings[0].Severity != types.SeverityError {
+		t.Fatalf("configuration errors must keep error severity, got %v", findings)
+	}
+}
+
+func TestRunAllInlineSuppressions(t *testing.T) {
+	arch := loadArch(t, "arch_suppressions.yaml")
+	opts := engine.Options{
+		EnabledRules: []string{"ARCH-ACL"},
+		Now:          time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	findings := engine.RunAll(arch, opts)
+
+	byClass := map[string]types.Finding{}
+	var suppressed, active []types.Finding
+	for _, f := range findings {
+		switch {
+		case f.RuleID == engine.SuppressionRuleID:
+			byClass[f.Class] = f
+		case f.Suppressed:
+			suppressed = append(suppressed, f)
+		default:
+			active = append(active, f)
+		}
+	}
+	if len(suppressed) != 1 || suppressed[0].Path != "boundaries[0].relations[2]" {
+		t.Fatalf("expected api -> audit to be suppressed, got %v", suppressed)
+	}
+	if suppressed[0].Meta["suppressionReason"] != "Audit client predates the ACL gateway." {
+		t.Fatalf("missing suppression reason: %v", suppressed[0].Meta)
+	}
+	if len(active) != 1 || active[0].Path != "boundaries[0].relations[3]" {
+		t.Fatalf("expired suppression must not hide legacy -> audit, got %v", active)
+	}
+	for class, path := range map[string]string{
+		"expired":        "boundaries[0].relations[3].archlint",
+		"unused":         "boundaries[0].relations[4].archlint",
+		"missing-reason": "boundaries[0].archlint",
+	} {
+		if f, ok := byClass[class]; !ok || f.Path != path {
+			t.Fatalf("expected %s finding at %s, got %v", class, path, findings)
+		}
+	}
+	if len(byClass) != 3 {
+		t.Fatalf("ARCH-BOUNDARIES did not run, so no unused finding for it: %v", byClass)
+	}
+}
+
+func TestRunAllSuppressionsCoverRelations(t *testing.T) {
+	ignoreACL := func(reason string) *model.Suppression {
+		return &model.Suppression{Ignore: []string{"ARCH-ACL"}, Reason: reason}
+	}
+	arch := &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{
+			{
+				Name: "Core",
+				Containers: []model.Container{
+					{Name: "legacy", Type: model.ContainerService, Archlint: ignoreACL("Retired with billing v3.")},
+					{Name: "api", Type: model.ContainerService},
+				},
+				Relations: []model.Relation{
+					{From: "legacy", To: "audit", Kind: model.RelationKindSync},
+					{From: "api", To: "audit", Kind: model.RelationKindSync},
+					{From: "web", To: "audit", Kind: model.RelationKindSync},
+				},
+			},
+			{
+				Name:       "Edge",
+				Archlint:   ignoreACL("Edge calls are audited upstream."),
+				Containers: []model.Container{{Name: "web", Type: model.ContainerService}},
+			},
+		},
+		Externals: []model.Container{{Name: "audit", Type: model.ContainerExternal}},
+	}
+
+	findings := engine.RunAll(arch, engine.Options{EnabledRules: []string{"ARCH-ACL"}})
+	suppressedBy := map[string]string{}
+	for _, f := range findings {
+		if f.RuleID == engine.SuppressionRuleID {
+			t.Fatalf("both suppressions cover a finding, got %v", f)
+		}
+		by, _ := f.Meta["suppressedBy"].(string)
+		suppressedBy[f.Path] = by
+	}
+	want := map[string]string{
+		"boundaries[0].relations[0]": "boundaries[0].containers[0]",
+		"boundaries[0].relations[1]": "",
+		"boundaries[0].relations[2]": "boundaries[1]",
+	}
+	if len(suppressedBy) != len(want) {
+		t.Fatalf("expected three ACL findings, got %v", findings)
+	}
+	for path, by := range want {
+		if got, ok := suppressedBy[path]; !ok || got != by {
+			t.Fatalf("finding at %s: expected suppressedBy %q, got %q (%v)", path, by, got, findings)
+		}
+	}
+}
+
 func TestRunAllMusicStreamingExample(t *testing.T) {
 	arch := loadExample(t, "music_streaming.yaml")
 	findings := engine.RunAll(arch, engine.Options{})
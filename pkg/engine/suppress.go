@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/checks"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// SuppressionRuleID reports problems with inline archlint suppressions.
+const SuppressionRuleID = "ARCH-SUPPRESSION"
+
+// Message classes of SuppressionRuleID findings.
+const (
+	classSuppressionExpired = "expired"
+	classSuppressionUnused  = "unused"
+	classSuppressionReason  = "missing-reason"
+	classSuppressionInvalid = "invalid-expires"
+)
+
+const expiresLayout = "2006-01-02"
+
+// SuppressionRuleMetadata describes the findings emitted for inline suppressions.
+func SuppressionRuleMetadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              SuppressionRuleID,
+		Description:     "Inline archlint suppressions must state a reason, stay in date and still match a finding.",
+		DefaultSeverity: types.SeverityWarn,
+		Rationale:       "Exceptions should be deliberate, explained and temporary; stale ones hide the next real violation.",
+		DocURL:          types.RuleDocURL(SuppressionRuleID),
+		Classes:         []string{classSuppressionExpired, classSuppressionUnused, classSuppressionReason, classSuppressionInvalid},
+		ClassSeverities: map[string]types.Severity{classSuppressionInvalid: types.SeverityError},
+	}
+}
+
+type activeSuppression struct {
+	ref  model.SuppressionRef
+	used map[string]bool
+	// containers holds the names of the containers inside the annotated
+	// element; relations from or to them are covered as well.
+	containers map[string]bool
+}
+
+// applySuppressions flags findings covered by an active inline suppression
+// and returns findings about the suppressions themselves. Only rules in ran
+// can make a suppression count as unused.
+func applySuppressions(m *model.Architecture, findings []types.Finding, ran map[string]bool, now time.Time) []types.Finding {
+	refs := m.Suppressions()
+	if len(refs) == 0 {
+		return nil
+	}
+
+	today := now.Format(expiresLayout)
+	problems := make([]types.Finding, 0)
+	active := make([]*activeSuppression, 0, len(refs))
+	for _, ref := range refs {
+		s := ref.Suppression
+		if strings.TrimSpace(s.Reason) == "" {
+			problems = append(problems, suppressionFinding(ref, types.SeverityWarn, classSuppressionReason,
+				fmt.Sprintf("suppression of %s has no reason", strings.Join(s.Ignore, ", "))))
+		}
+		if s.Expires != "" {
+			if _, err := time.Parse(expiresLayout, s.Expires); err != nil {
+				problems = append(problems, suppressionFinding(ref, types.SeverityError, classSuppressionInvalid,
+					fmt.Sprintf("suppression expiry %q is not a YYYY-MM-DD date", s.Expires)))
+				continue
+			}
+			if s.Expires < today {
+				problems = append(problems, suppressionFinding(ref, types.SeverityWarn, classSuppressionExpired,
+					fmt.Sprintf("suppression of %s expired on %s", strings.Join(s.Ignore, ", "), s.Expires)))
+				continue
+			}
+		}
+		active = append(active, &activeSuppression{ref: ref, used: map[string]bool{}, containers: map[string]bool{}})
+	}
+	for _, c := range m.Containers() {
+		for _, a := range active {
+			if covers(a.ref.Path, c.Path) {
+				a.containers[c.Container.Name] = true
+			}
+		}
+	}
+	relations := m.Relations()
+
+	for i := range findings {
+		f := &findings[i]
+		if f.Class == checks.ClassConfig {
+			continue
+		}
+		if match := coveringSuppression(active, relations, f); match != nil {
+			match.used[f.RuleID] = true
+			f.Suppressed = true
+			if f.Meta == nil {
+				f.Meta = map[string]any{}
+			}
+			f.Meta["suppressedBy"] = match.ref.Path
+			if match.ref.Suppression.Reason != "" {
+				f.Meta["suppressionReason"] = match.ref.Suppression.Reason
+			}
+		}
+	}
+
+	for _, a := range active {
+		for _, id := range a.ref.Suppression.Ignore {
+			if ran[id] && !a.used[id] {
+				problems = append(problems, suppressionFinding(a.ref, types.SeverityWarn, classSuppressionUnused,
+					fmt.Sprintf("suppression of %s matches no finding", id)))
+			}
+		}
+	}
+	return problems
+}
+
+// coveringSuppression returns the innermost suppression ignoring f.RuleID
+// whose element contains f.Path. Failing that, a finding on a relation is
+// covered by the innermost suppression on a container it connects, or on a
+// boundary holding one.
+func coveringSuppression(active []*activeSuppression, relations []model.RelationRef, f *types.Finding) *activeSuppression {
+	var best, endpoint *activeSuppression
+	rel := relationAt(relations, f.Path)
+	for _, a := range active {
+		if !ignores(a.ref.Suppression, f.RuleID) {
+			continue
+		}
+		switch {
+		case covers(a.ref.Path, f.Path):
+			if best == nil || len(a.ref.Path) > len(best.ref.Path) {
+				best = a
+			}
+		case rel != nil && (a.containers[rel.From] || a.containers[rel.To]):
+			if endpoint == nil || len(a.ref.Path) > len(endpoint.ref.Path) {
+				endpoint = a
+			}
+		}
+	}
+	if best == nil {
+		return endpoint
+	}
+	return best
+}
+
+// relationAt returns the relation whose element contains path, if any.
+func relationAt(relations []model.RelationRef, path string) *model.Relation {
+	for _, ref := range relations {
+		if covers(ref.Path, path) {
+			return ref.Relation
+		}
+	}
+	return nil
+}
+
+func covers(element, path string) bool {
+	return path == element || strings.HasPrefix(path, element+".")
+}
+
+func ignores(s *model.Suppression, ruleID string) bool {
+	for _, id := range s.Ignore {
+		if id == ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+func suppressionFinding(ref model.SuppressionRef, severity types.Severity, class, message string) types.Finding {
+	return types.Finding{
+		RuleID:   SuppressionRuleID,
+		Severity: severity,
+		Message:  message,
+		Path:     ref.Path + ".archlint",
+		Location: model.LocationPtr(ref.Location),
+		Class:    class,
+	}
+}
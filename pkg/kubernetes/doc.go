@@ -0,0 +1,6 @@
+// Package kubernetes derives an architecture model from local Kubernetes
+// manifests. Deployments, StatefulSets and DaemonSets become containers
+// grouped into boundaries by namespace or a configurable label,
+// ExternalName Services become externals, and NetworkPolicy egress rules
+// become relations. Nothing talks to a cluster; only files are read.
+package kubernetes
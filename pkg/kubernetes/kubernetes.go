@@ -0,0 +1,468 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/PET-dev-projects/ArchLint/internal/images"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+const (
+	defaultNamespace = "default"
+
+	// namespaceNameLabel is set on every namespace by the API server.
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+
+	metaKind      = "k8s.kind"
+	metaNamespace = "k8s.namespace"
+	metaImage     = "k8s.image"
+	metaService   = "k8s.service"
+	metaExternal  = "k8s.externalName"
+)
+
+var workloadKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+}
+
+// Options tune the import.
+type Options struct {
+	// BoundaryLabel groups workloads by the value of this label (e.g.
+	// app.kubernetes.io/part-of) instead of their namespace. Workloads
+	// without the label fall back to their namespace.
+	BoundaryLabel string
+}
+
+// Warning describes a manifest construct that could not be converted.
+type Warning struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (w Warning) String() string {
+	switch {
+	case w.File != "" && w.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", w.File, w.Line, w.Message)
+	case w.Line > 0:
+		return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+	case w.File != "":
+		return fmt.Sprintf("%s: %s", w.File, w.Message)
+	}
+	return w.Message
+}
+
+type object struct {
+	manifest
+	file string
+	line int
+}
+
+type workload struct {
+	object
+	namespace string
+	podLabels map[string]string
+	boundary  string
+	container model.Container
+}
+
+type importer struct {
+	opts     Options
+	objects  []object
+	warnings []Warning
+}
+
+// Import reads a stream of (possibly multi-document) manifests.
+func Import(r io.Reader, opts Options) (*model.Architecture, []Warning, error) {
+	imp := &importer{opts: opts}
+	if err := imp.read(r, ""); err != nil {
+		return nil, nil, err
+	}
+	return imp.build()
+}
+
+// ImportDir reads every .yaml/.yml file under path, or path itself when it is
+// a file.
+func ImportDir(path string, opts Options) (*model.Architecture, []Warning, error) {
+	imp := &importer{opts: opts}
+	err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(file); file != path && ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		fh, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer fh.Close()
+		return imp.read(fh, file)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return imp.build()
+}
+
+func (imp *importer) read(r io.Reader, file string) error {
+	dec := yaml.NewDecoder(r)
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if file != "" {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			return err
+		}
+		if err := imp.add(&doc, file); err != nil {
+			return err
+		}
+	}
+}
+
+func (imp *importer) add(node *yaml.Node, file string) error {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	var m manifest
+	if err := node.Decode(&m); err != nil {
+		return fmt.Errorf("%s line %d: %w", file, node.Line, err)
+	}
+	if len(m.Items) > 0 && strings.HasSuffix(m.Kind, "List") {
+		for i := range m.Items {
+			if err := imp.add(&m.Items[i], file); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if m.Kind != "" {
+		imp.objects = append(imp.objects, object{manifest: m, file: file, line: node.Line})
+	}
+	return nil
+}
+
+func (imp *importer) build() (*model.Architecture, []Warning, error) {
+	namespaces := map[string]map[string]string{}
+	workloads := make([]*workload, 0)
+	externals := make([]object, 0)
+	services := make([]object, 0)
+	policies := make([]object, 0)
+
+	for _, obj := range imp.objects {
+		switch {
+		case obj.Kind == "Namespace":
+			labels := map[string]string{namespaceNameLabel: obj.Metadata.Name}
+			for k, v := range obj.Metadata.Labels {
+				labels[k] = v
+			}
+			namespaces[obj.Metadata.Name] = labels
+		case workloadKinds[obj.Kind]:
+			w, err := imp.workload(obj)
+			if err != nil {
+				return nil, nil, err
+			}
+			workloads = append(workloads, w)
+		case obj.Kind == "Service":
+			var spec serviceSpec
+			if err := obj.Spec.Decode(&spec); err != nil {
+				return nil, nil, imp.errorf(obj, err)
+			}
+			if spec.Type == "ExternalName" {
+				externals = append(externals, obj)
+			} else {
+				services = append(services, obj)
+			}
+		case obj.Kind == "NetworkPolicy":
+			policies = append(policies, obj)
+		}
+	}
+	if len(workloads) == 0 {
+		return nil, nil, errors.New("no Deployments, StatefulSets or DaemonSets found")
+	}
+
+	for _, svc := range services {
+		var spec serviceSpec
+		_ = svc.Spec.Decode(&spec)
+		matched := false
+		for _, w := range workloads {
+			if w.namespace != namespaceOf(svc) || len(spec.Selector) == 0 || !(labelSelector{MatchLabels: spec.Selector}).matches(w.podLabels) {
+				continue
+			}
+			matched = true
+			if existing := w.container.Meta[metaService]; existing != "" {
+				w.container.Meta[metaService] = existing + "," + svc.Metadata.Name
+			} else {
+				w.container.Meta[metaService] = svc.Metadata.Name
+			}
+		}
+		if !matched {
+			imp.warn(svc, "service %s/%s selects no imported workload", namespaceOf(svc), svc.Metadata.Name)
+		}
+	}
+
+	externalContainers := make([]model.Container, 0, len(externals))
+	for _, ext := range externals {
+		var spec serviceSpec
+		_ = ext.Spec.Decode(&spec)
+		externalContainers = append(externalContainers, model.Container{
+			Name: ext.Metadata.Name,
+			Type: model.ContainerExternal,
+			Meta: model.Metadata{metaNamespace: namespaceOf(ext), metaExternal: spec.ExternalName},
+		})
+	}
+	workloads, externalContainers = imp.qualifyNames(workloads, externals, externalContainers)
+
+	order := make([]string, 0)
+	boundaries := map[string]*model.Boundary{}
+	for _, w := range workloads {
+		b, ok := boundaries[w.boundary]
+		if !ok {
+			b = &model.Boundary{Name: w.boundary, Containers: []model.Container{}}
+			boundaries[w.boundary] = b
+			order = append(order, w.boundary)
+		}
+		b.Containers = append(b.Containers, w.container)
+	}
+
+	seen := map[[2]string]bool{}
+	for _, policy := range policies {
+		for _, rel := range imp.egressRelations(policy, workloads, namespaces) {
+			key := [2]string{rel.source.container.Name, rel.target.container.Name}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			b := boundaries[rel.source.boundary]
+			b.Relations = append(b.Relations, rel.relation)
+		}
+	}
+
+	sort.Strings(order)
+	arch := &model.Architecture{Version: 1}
+	for _, name := range order {
+		arch.Boundaries = append(arch.Boundaries, *boundaries[name])
+	}
+	if len(externalContainers) > 0 {
+		arch.Externals = externalContainers
+	}
+	return arch, imp.warnings, nil
+}
+
+func (imp *importer) workload(obj object) (*workload, error) {
+	var spec workloadSpec
+	if err := obj.Spec.Decode(&spec); err != nil {
+		return nil, imp.errorf(obj, err)
+	}
+	w := &workload{
+		object:    obj,
+		namespace: namespaceOf(obj),
+		podLabels: spec.Template.Metadata.Labels,
+	}
+	w.boundary = w.namespace
+	if key := imp.opts.BoundaryLabel; key != "" {
+		if value := obj.Metadata.Labels[key]; value != "" {
+			w.boundary = value
+		} else if value := w.podLabels[key]; value != "" {
+			w.boundary = value
+		}
+	}
+
+	c := model.Container{
+		Name: obj.Metadata.Name,
+		Type: model.ContainerService,
+		Meta: model.Metadata{metaKind: obj.Kind, metaNamespace: w.namespace},
+	}
+	if containers := spec.Template.Spec.Containers; len(containers) > 0 && containers[0].Image != "" {
+		c.Meta[metaImage] = containers[0].Image
+		if tech, ok := images.Database(containers[0].Image); ok {
+			c.Type = model.ContainerDatabase
+			c.Technology = tech
+		}
+	}
+	w.container = c
+	return w, nil
+}
+
+// qualifyNames makes container names unique, since a model rejects
+// duplicates. An object declared twice (same kind, namespace and name) is
+// skipped with a warning. A name still used more than once gets
+// ".<namespace>"; when that is not enough (a Deployment and a StatefulSet
+// sharing a name in one namespace) it becomes "<name>.<kind>.<namespace>".
+func (imp *importer) qualifyNames(workloads []*workload, externals []object, externalContainers []model.Container) ([]*workload, []model.Container) {
+	seen := map[string]bool{}
+	keep := func(obj object) bool {
+		key := obj.Kind + "/" + namespaceOf(obj) + "/" + obj.Metadata.Name
+		if seen[key] {
+			imp.warn(obj, "%s %s/%s is declared more than once; skipped", obj.Kind, namespaceOf(obj), obj.Metadata.Name)
+			return false
+		}
+		seen[key] = true
+		return true
+	}
+	type entry struct {
+		obj  object
+		name *string
+	}
+	entries := make([]entry, 0, len(workloads)+len(externals))
+	keptWorkloads := make([]*workload, 0, len(workloads))
+	for _, w := range workloads {
+		if keep(w.object) {
+			keptWorkloads = append(keptWorkloads, w)
+			entries = append(entries, entry{obj: w.object, name: &w.container.Name})
+		}
+	}
+	keptExternals := make([]model.Container, 0, len(externalContainers))
+	keptObjects := make([]object, 0, len(externals))
+	for i, obj := range externals {
+		if keep(obj) {
+			keptExternals = append(keptExternals, externalContainers[i])
+			keptObjects = append(keptObjects, obj)
+		}
+	}
+	for i := range keptExternals {
+		entries = append(entries, entry{obj: keptObjects[i], name: &keptExternals[i].Name})
+	}
+
+	qualify := func(format func(obj object) string) {
+		count := map[string]int{}
+		for _, e := range entries {
+			count[*e.name]++
+		}
+		for _, e := range entries {
+			if count[*e.name] > 1 {
+				*e.name = format(e.obj)
+			}
+		}
+	}
+	qualify(func(obj object) string { return obj.Metadata.Name + "." + namespaceOf(obj) })
+	qualify(func(obj object) string {
+		return obj.Metadata.Name + "." + strings.ToLower(obj.Kind) + "." + namespaceOf(obj)
+	})
+	return keptWorkloads, keptExternals
+}
+
+type egressRelation struct {
+	source   *workload
+	target   *workload
+	relation model.Relation
+}
+
+func (imp *importer) egressRelations(policy object, workloads []*workload, namespaces map[string]map[string]string) []egressRelation {
+	var spec networkPolicySpec
+	if err := policy.Spec.Decode(&spec); err != nil {
+		imp.warn(policy, "network policy %s: %v", policy.Metadata.Name, err)
+		return nil
+	}
+	namespace := namespaceOf(policy)
+	sources := make([]*workload, 0)
+	for _, w := range workloads {
+		if w.namespace == namespace && spec.PodSelector.matches(w.podLabels) {
+			sources = append(sources, w)
+		}
+	}
+	if len(sources) == 0 || len(spec.Egress) == 0 {
+		return nil
+	}
+
+	rels := make([]egressRelation, 0)
+	for _, rule := range spec.Egress {
+		if len(rule.To) == 0 {
+			imp.warn(policy, "network policy %s/%s allows egress to any destination; no relation inferred", namespace, policy.Metadata.Name)
+			continue
+		}
+		protocol := portProtocol(rule.Ports)
+		for _, peer := range rule.To {
+			if peer.IPBlock != nil {
+				imp.warn(policy, "network policy %s/%s egress to ipBlock %s cannot be mapped to a container", namespace, policy.Metadata.Name, peer.IPBlock.CIDR)
+				continue
+			}
+			for _, target := range workloads {
+				if !peerMatches(peer, namespace, target, namespaces) {
+					continue
+				}
+				for _, source := range sources {
+					if source == target {
+						continue
+					}
+					kind := model.RelationKindSync
+					if target.container.Type == model.ContainerDatabase {
+						kind = model.RelationKindDB
+					}
+					rels = append(rels, egressRelation{source: source, target: target, relation: model.Relation{
+						From:        source.container.Name,
+						To:          target.container.Name,
+						Kind:        kind,
+						Protocol:    protocol,
+						Description: "NetworkPolicy " + namespace + "/" + policy.Metadata.Name,
+					}})
+				}
+			}
+		}
+	}
+	return rels
+}
+
+func peerMatches(peer networkPolicyPeer, policyNamespace string, target *workload, namespaces map[string]map[string]string) bool {
+	if peer.NamespaceSelector == nil {
+		if target.namespace != policyNamespace {
+			return false
+		}
+	} else {
+		labels, ok := namespaces[target.namespace]
+		if !ok {
+			labels = map[string]string{namespaceNameLabel: target.namespace}
+		}
+		if !peer.NamespaceSelector.matches(labels) {
+			return false
+		}
+	}
+	return peer.PodSelector == nil || peer.PodSelector.matches(target.podLabels)
+}
+
+// portProtocol renders the first egress port as e.g. "tcp:5432".
+func portProtocol(ports []networkPolicyPort) string {
+	if len(ports) == 0 || ports[0].Port.Value == "" {
+		return ""
+	}
+	protocol := ports[0].Protocol
+	if protocol == "" {
+		protocol = "TCP"
+	}
+	return strings.ToLower(protocol) + ":" + ports[0].Port.Value
+}
+
+func (imp *importer) warn(obj object, format string, args ...any) {
+	imp.warnings = append(imp.warnings, Warning{File: obj.file, Line: obj.line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (imp *importer) errorf(obj object, err error) error {
+	return fmt.Errorf("%s %s: %w", obj.Kind, obj.Metadata.Name, err)
+}
+
+func namespaceOf(obj object) string {
+	if obj.Metadata.Namespace == "" {
+		return defaultNamespace
+	}
+	return obj.Metadata.Namespace
+}
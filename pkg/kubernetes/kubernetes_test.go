@@ -0,0 +1,135 @@
+package kubernetes_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/kubernetes"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+func TestImportDir(t *testing.T) {
+	dir := filepath.Join("..", "..", "testdata", "k8s")
+	arch, warnings, err := kubernetes.ImportDir(dir, kubernetes.Options{})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "ipBlock 203.0.113.0/24") || warnings[0].Line == 0 {
+		t.Fatalf("expected ipBlock warning, got %v", warnings)
+	}
+	if len(arch.Boundaries) != 2 || arch.Boundaries[0].Name != "billing" || arch.Boundaries[1].Name != "shop" {
+		t.Fatalf("expected namespace boundaries, got %+v", arch.Boundaries)
+	}
+
+	shop := arch.Boundaries[1]
+	names := make([]string, 0)
+	for _, c := range shop.Containers {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "web.shop,orders,orders-db" {
+		t.Fatalf("expected clashing names to be qualified, got %v", names)
+	}
+	if db := shop.Containers[2]; db.Type != model.ContainerDatabase || db.Technology != "postgres" {
+		t.Fatalf("expected postgres database, got %+v", db)
+	}
+	if orders := shop.Containers[1]; orders.Meta["k8s.service"] != "orders-api" {
+		t.Fatalf("expected service mapped to workload, got %+v", orders.Meta)
+	}
+	if len(arch.Externals) != 1 || arch.Externals[0].Meta["k8s.externalName"] != "api.psp.example" {
+		t.Fatalf("expected ExternalName external, got %+v", arch.Externals)
+	}
+
+	var got []string
+	for _, rel := range shop.Relations {
+		got = append(got, rel.From+">"+rel.To+":"+string(rel.Kind)+":"+rel.Protocol)
+	}
+	want := "web.shop>orders:sync:tcp:8080 orders>orders-db:db:tcp:5432 orders>billing:sync:"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("unexpected relations:\n got %s\nwant %s", strings.Join(got, " "), want)
+	}
+
+	var buf bytes.Buffer
+	if err := model.WriteYAML(&buf, arch); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	loaded, err := model.LoadModelFromYAML(&buf)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if findings := model.ValidateModel(loaded); len(findings) != 0 {
+		t.Fatalf("imported model does not validate: %v", findings)
+	}
+}
+
+func TestImportBoundaryLabel(t *testing.T) {
+	src := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: billing
+  namespace: billing
+  labels:
+    app.kubernetes.io/part-of: payments
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ledger
+  namespace: billing
+`
+	arch, _, err := kubernetes.Import(strings.NewReader(src), kubernetes.Options{BoundaryLabel: "app.kubernetes.io/part-of"})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(arch.Boundaries) != 2 || arch.Boundaries[0].Name != "billing" || arch.Boundaries[1].Name != "payments" {
+		t.Fatalf("expected label boundary with namespace fallback, got %+v", arch.Boundaries)
+	}
+
+	if _, _, err := kubernetes.Import(strings.NewReader("apiVersion: v1\nkind: ConfigMap\n"), kubernetes.Options{}); err == nil {
+		t.Fatal("expected error without workloads")
+	}
+}
+
+func TestImportNameClashesInOneNamespace(t *testing.T) {
+	src := `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: db, namespace: x}
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata: {name: db, namespace: x}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: db, namespace: x}
+spec: {type: ExternalName, externalName: db.example.com}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: db, namespace: y}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: db, namespace: y}
+`
+	arch, warnings, err := kubernetes.Import(strings.NewReader(src), kubernetes.Options{})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	names := make([]string, 0)
+	for _, ref := range arch.Containers() {
+		names = append(names, ref.Container.Name)
+	}
+	if got := strings.Join(names, ","); got != "db.deployment.x,db.statefulset.x,db.y,db.service.x" {
+		t.Fatalf("expected names qualified by kind where the namespace is not enough, got %s", got)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "Deployment y/db is declared more than once") {
+		t.Fatalf("expected a warning for the repeated Deployment, got %v", warnings)
+	}
+	if findings := model.ValidateModel(arch); len(findings) != 0 {
+		t.Fatalf("imported model does not validate: %v", findings)
+	}
+}
@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+type objectMeta struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace"`
+	Labels    map[string]string `yaml:"labels"`
+}
+
+type manifest struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   objectMeta  `yaml:"metadata"`
+	Spec       yaml.Node   `yaml:"spec"`
+	Items      []yaml.Node `yaml:"items"`
+}
+
+type podContainer struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+}
+
+type workloadSpec struct {
+	Template struct {
+		Metadata objectMeta `yaml:"metadata"`
+		Spec     struct {
+			Containers []podContainer `yaml:"containers"`
+		} `yaml:"spec"`
+	} `yaml:"template"`
+}
+
+type serviceSpec struct {
+	Type         string            `yaml:"type"`
+	ExternalName string            `yaml:"externalName"`
+	Selector     map[string]string `yaml:"selector"`
+}
+
+type networkPolicyPeer struct {
+	PodSelector       *labelSelector `yaml:"podSelector"`
+	NamespaceSelector *labelSelector `yaml:"namespaceSelector"`
+	IPBlock           *struct {
+		CIDR string `yaml:"cidr"`
+	} `yaml:"ipBlock"`
+}
+
+type networkPolicyPort struct {
+	Protocol string    `yaml:"protocol"`
+	Port     yaml.Node `yaml:"port"`
+}
+
+type networkPolicySpec struct {
+	PodSelector labelSelector `yaml:"podSelector"`
+	PolicyTypes []string      `yaml:"policyTypes"`
+	Egress      []struct {
+		To    []networkPolicyPeer `yaml:"to"`
+		Ports []networkPolicyPort `yaml:"ports"`
+	} `yaml:"egress"`
+}
+
+type labelSelector struct {
+	MatchLabels      map[string]string `yaml:"matchLabels"`
+	MatchExpressions []struct {
+		Key      string   `yaml:"key"`
+		Operator string   `yaml:"operator"`
+		Values   []string `yaml:"values"`
+	} `yaml:"matchExpressions"`
+}
+
+// matches implements Kubernetes label selector semantics; an empty selector
+// matches everything.
+func (s labelSelector) matches(labels map[string]string) bool {
+	for key, want := range s.MatchLabels {
+		if labels[key] != want {
+			return false
+		}
+	}
+	for _, expr := range s.MatchExpressions {
+		value, ok := labels[expr.Key]
+		switch expr.Operator {
+		case "In":
+			if !ok || !contains(expr.Values, value) {
+				return false
+			}
+		case "NotIn":
+			if ok && contains(expr.Values, value) {
+				return false
+			}
+		case "Exists":
+			if !ok {
+				return false
+			}
+		case "DoesNotExist":
+			if ok {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
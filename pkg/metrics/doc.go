@@ -0,0 +1,5 @@
+// Package metrics computes architecture statistics for trend tracking:
+// container and relation counts, cross-boundary coupling, cycles, the longest
+// synchronous call chain and external integrations per boundary. Results can
+// be written as JSON, CSV or the Prometheus text format.
+package metrics
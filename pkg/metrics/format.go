@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PrometheusPrefix is prepended to every metric name in the Prometheus format.
+const PrometheusPrefix = "archlint_"
+
+var help = map[string]string{
+	"containers":                  "Containers in the model, externals included.",
+	"containers_by_type":          "Containers per type.",
+	"relations":                   "Relations in the model.",
+	"relations_by_kind":           "Relations per kind.",
+	"cross_boundary_relations":    "Relations whose endpoints sit in different boundaries.",
+	"cycles":                      "Groups of containers that depend on each other.",
+	"longest_sync_chain":          "Sync relations in the longest synchronous call chain.",
+	"boundary_containers":         "Containers per boundary, nested boundaries included.",
+	"boundary_internal_relations": "Outgoing relations staying inside the boundary.",
+	"boundary_cross_relations":    "Outgoing relations leaving the boundary.",
+	"boundary_externals":          "Distinct external containers called from the boundary.",
+}
+
+// WriteJSON serializes the report to JSON.
+func WriteJSON(w io.Writer, r Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes one "metric,labels,value" row per sample; labels are
+// rendered as key=value pairs joined by ";".
+func WriteCSV(w io.Writer, r Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"metric", "labels", "value"}); err != nil {
+		return err
+	}
+	for _, s := range r.Samples() {
+		pairs := make([]string, 0, len(s.Labels))
+		for _, l := range s.Labels {
+			pairs = append(pairs, l.Key+"="+l.Value)
+		}
+		if err := cw.Write([]string{s.Name, strings.Join(pairs, ";"), formatValue(s.Value)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WritePrometheus writes the report in the Prometheus text exposition
+// format, suitable for a Pushgateway or a node_exporter textfile collector.
+func WritePrometheus(w io.Writer, r Report) error {
+	previous := ""
+	for _, s := range r.Samples() {
+		name := PrometheusPrefix + s.Name
+		if s.Name != previous {
+			if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help[s.Name], name); err != nil {
+				return err
+			}
+			previous = s.Name
+		}
+		if len(s.Labels) > 0 {
+			pairs := make([]string, 0, len(s.Labels))
+			for _, l := range s.Labels {
+				pairs = append(pairs, fmt.Sprintf("%s=%q", l.Key, l.Value))
+			}
+			name += "{" + strings.Join(pairs, ",") + "}"
+		}
+		if _, err := fmt.Fprintf(w, "%s %s\n", name, formatValue(s.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
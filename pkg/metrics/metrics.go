@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"sort"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/checks"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// Report holds the statistics of one architecture model.
+type Report struct {
+	Containers             int            `json:"containers"`
+	ContainersByType       map[string]int `json:"containersByType"`
+	Relations              int            `json:"relations"`
+	RelationsByKind        map[string]int `json:"relationsByKind"`
+	CrossBoundaryRelations int            `json:"crossBoundaryRelations"`
+	// Cycles counts groups of containers that depend on each other.
+	Cycles int `json:"cycles"`
+	// LongestSyncChain is the number of sync relations in LongestSyncPath.
+	LongestSyncChain int        `json:"longestSyncChain"`
+	LongestSyncPath  []string   `json:"longestSyncPath,omitempty"`
+	Boundaries       []Boundary `json:"boundaries"`
+}
+
+// Boundary holds per-boundary statistics; nested boundaries count towards
+// their parents.
+type Boundary struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Containers int    `json:"containers"`
+	// Internal and Cross count outgoing relations staying inside or leaving
+	// the boundary.
+	Internal int `json:"internal"`
+	Cross    int `json:"cross"`
+	// Externals counts distinct external containers the boundary calls.
+	Externals int `json:"externals"`
+}
+
+// Collect computes the statistics of m.
+func Collect(m *model.Architecture) Report {
+	r := Report{
+		ContainersByType: map[string]int{},
+		RelationsByKind:  map[string]int{},
+		Boundaries:       make([]Boundary, 0),
+	}
+
+	containers := m.ContainerMap()
+	for _, ref := range m.Containers() {
+		r.Containers++
+		r.ContainersByType[string(ref.Container.Type)]++
+	}
+
+	relations := m.Relations()
+	for _, ref := range relations {
+		rel := ref.Relation
+		r.Relations++
+		r.RelationsByKind[string(rel.Kind)]++
+		from, okFrom := containers[rel.From]
+		to, okTo := containers[rel.To]
+		if okFrom && okTo && from.Boundary != to.Boundary {
+			r.CrossBoundaryRelations++
+		}
+	}
+
+	r.Cycles = len(checks.Cycles(m))
+	if chain := checks.LongestSyncChain(m); len(chain) > 0 {
+		r.LongestSyncChain = len(chain) - 1
+		r.LongestSyncPath = chain
+	}
+
+	for _, stats := range checks.CollectBoundaryStats(m) {
+		members := make(map[string]bool, len(stats.Containers))
+		for _, name := range stats.Containers {
+			members[name] = true
+		}
+		externals := map[string]bool{}
+		for _, ref := range relations {
+			rel := ref.Relation
+			to, ok := containers[rel.To]
+			if members[rel.From] && ok && to.Container.Type == model.ContainerExternal {
+				externals[rel.To] = true
+			}
+		}
+		r.Boundaries = append(r.Boundaries, Boundary{
+			Name:       stats.Name,
+			Path:       stats.Path,
+			Containers: len(stats.Containers),
+			Internal:   stats.Internal,
+			Cross:      stats.Cross,
+			Externals:  len(externals),
+		})
+	}
+	return r
+}
+
+// Sample is one labelled value of a Report, the unit of the CSV and
+// Prometheus formats.
+type Sample struct {
+	Name   string
+	Labels []Label
+	Value  float64
+}
+
+// Label is a key/value pair qualifying a Sample.
+type Label struct {
+	Key   string
+	Value string
+}
+
+// Samples flattens r into samples in a stable order, keeping samples of the
+// same metric together.
+func (r Report) Samples() []Sample {
+	samples := []Sample{{Name: "containers", Value: float64(r.Containers)}}
+	for _, key := range sortedKeys(r.ContainersByType) {
+		samples = append(samples, Sample{Name: "containers_by_type", Labels: []Label{{"type", key}}, Value: float64(r.ContainersByType[key])})
+	}
+	samples = append(samples, Sample{Name: "relations", Value: float64(r.Relations)})
+	for _, key := range sortedKeys(r.RelationsByKind) {
+		samples = append(samples, Sample{Name: "relations_by_kind", Labels: []Label{{"kind", key}}, Value: float64(r.RelationsByKind[key])})
+	}
+	samples = append(samples,
+		Sample{Name: "cross_boundary_relations", Value: float64(r.CrossBoundaryRelations)},
+		Sample{Name: "cycles", Value: float64(r.Cycles)},
+		Sample{Name: "longest_sync_chain", Value: float64(r.LongestSyncChain)},
+	)
+	perBoundary := []struct {
+		name  string
+		value func(Boundary) int
+	}{
+		{"boundary_containers", func(b Boundary) int { return b.Containers }},
+		{"boundary_internal_relations", func(b Boundary) int { return b.Internal }},
+		{"boundary_cross_relations", func(b Boundary) int { return b.Cross }},
+		{"boundary_externals", func(b Boundary) int { return b.Externals }},
+	}
+	for _, metric := range perBoundary {
+		for _, b := range r.Boundaries {
+			labels := []Label{{"boundary", b.Name}, {"path", b.Path}}
+			samples = append(samples, Sample{Name: metric.name, Labels: labels, Value: float64(metric.value(b))})
+		}
+	}
+	return samples
+}
+
+func sortedKeys(values map[string]int) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,122 @@
+package metrics_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/metrics"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+func testModel() *model.Architecture {
+	return &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{{
+			Name: "Shop",
+			Containers: []model.Container{
+				{Name: "web", Type: model.ContainerService},
+				{Name: "orders", Type: model.ContainerService},
+				{Name: "orders-db", Type: model.ContainerDatabase},
+			},
+			Boundaries: []model.Boundary{{
+				Name: "Billing",
+				Containers: []model.Container{
+					{Name: "billing", Type: model.ContainerService},
+				},
+				Relations: []model.Relation{
+					{From: "billing", To: "stripe", Kind: model.RelationKindSync},
+					{From: "billing", To: "orders", Kind: model.RelationKindAsync},
+				},
+			}},
+			Relations: []model.Relation{
+				{From: "web", To: "orders", Kind: model.RelationKindSync},
+				{From: "orders", To: "billing", Kind: model.RelationKindSync},
+				{From: "orders", To: "orders-db", Kind: model.RelationKindDB},
+			},
+		}},
+		Externals: []model.Container{{Name: "stripe", Type: model.ContainerExternal}},
+	}
+}
+
+func TestCollect(t *testing.T) {
+	r := metrics.Collect(testModel())
+
+	if r.Containers != 5 || r.ContainersByType["service"] != 3 || r.ContainersByType["external"] != 1 {
+		t.Fatalf("unexpected container counts: %+v", r)
+	}
+	if r.Relations != 5 || r.RelationsByKind["sync"] != 3 || r.RelationsByKind["async"] != 1 {
+		t.Fatalf("unexpected relation counts: %+v", r)
+	}
+	if r.CrossBoundaryRelations != 3 {
+		t.Fatalf("expected 3 cross-boundary relations, got %d", r.CrossBoundaryRelations)
+	}
+	if r.Cycles != 1 {
+		t.Fatalf("expected orders <-> billing cycle, got %d", r.Cycles)
+	}
+	if r.LongestSyncChain != 3 || strings.Join(r.LongestSyncPath, ">") != "web>orders>billing>stripe" {
+		t.Fatalf("unexpected sync chain: %d %v", r.LongestSyncChain, r.LongestSyncPath)
+	}
+	if len(r.Boundaries) != 2 {
+		t.Fatalf("expected two boundaries, got %+v", r.Boundaries)
+	}
+	shop, billing := r.Boundaries[0], r.Boundaries[1]
+	if shop.Containers != 4 || shop.Internal != 4 || shop.Cross != 1 || shop.Externals != 1 {
+		t.Fatalf("unexpected Shop metrics: %+v", shop)
+	}
+	if billing.Path != "boundaries[0].boundaries[0]" || billing.Cross != 2 || billing.Externals != 1 {
+		t.Fatalf("unexpected Billing metrics: %+v", billing)
+	}
+}
+
+func TestCollectLongestSyncChainThroughCycle(t *testing.T) {
+	var containers []model.Container
+	for _, name := range []string{"a", "b", "c", "x", "y", "z"} {
+		containers = append(containers, model.Container{Name: name, Type: model.ContainerService})
+	}
+	var relations []model.Relation
+	for _, edge := range []string{"a>b", "a>c", "b>c", "c>b", "b>x", "x>y", "y>z"} {
+		from, to, _ := strings.Cut(edge, ">")
+		relations = append(relations, model.Relation{From: from, To: to, Kind: model.RelationKindSync})
+	}
+	arch := &model.Architecture{
+		Version:    1,
+		Boundaries: []model.Boundary{{Name: "Graph", Containers: containers, Relations: relations}},
+	}
+
+	r := metrics.Collect(arch)
+	if r.LongestSyncChain != 5 || strings.Join(r.LongestSyncPath, ">") != "a>c>b>x>y>z" {
+		t.Fatalf("expected a>c>b>x>y>z, got %d %v", r.LongestSyncChain, r.LongestSyncPath)
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	r := metrics.Collect(testModel())
+
+	var csv bytes.Buffer
+	if err := metrics.WriteCSV(&csv, r); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	for _, want := range []string{
+		"metric,labels,value\ncontainers,,5\n",
+		"relations_by_kind,kind=db,1\n",
+		"boundary_externals,boundary=Billing;path=boundaries[0].boundaries[0],1\n",
+	} {
+		if !strings.Contains(csv.String(), want) {
+			t.Fatalf("expected %q in CSV:\n%s", want, csv.String())
+		}
+	}
+
+	var prom bytes.Buffer
+	if err := metrics.WritePrometheus(&prom, r); err != nil {
+		t.Fatalf("write prometheus: %v", err)
+	}
+	out := prom.String()
+	if strings.Count(out, "# TYPE archlint_boundary_cross_relations gauge") != 1 {
+		t.Fatalf("expected a single TYPE line per metric:\n%s", out)
+	}
+	if !strings.Contains(out, `archlint_containers_by_type{type="database"} 1`+"\n") ||
+		!strings.Contains(out, `archlint_longest_sync_chain 3`+"\n") {
+		t.Fatalf("unexpected prometheus output:\n%s", out)
+	}
+}
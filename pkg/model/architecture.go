@@ -1,26 +1,46 @@
 package model
 
+import "github.com/PET-dev-projects/ArchLint/pkg/types"
+
 // Architecture represents the full architecture YAML document.
 type Architecture struct {
 	Version    int         `yaml:"version"`
+	Imports    []string    `yaml:"imports,omitempty"`
 	Boundaries []Boundary  `yaml:"boundaries"`
 	Externals  []Container `yaml:"externals,omitempty"`
 	Meta       Metadata    `yaml:"meta,omitempty"`
+
+	// Location is the document position, populated by the YAML loader.
+	Location types.Location `yaml:"-"`
 }
 
 // Metadata allows attaching arbitrary key/value pairs.
 type Metadata map[string]string
 
+// Suppression is the inline `archlint:` annotation that silences rules for
+// an element and everything nested in it.
+type Suppression struct {
+	Ignore []string `yaml:"ignore"`
+	Reason string   `yaml:"reason,omitempty"`
+	// Expires is an inclusive YYYY-MM-DD date after which the suppression
+	// stops applying.
+	Expires string `yaml:"expires,omitempty"`
+}
+
 // Boundary is a logical grouping of containers and relations.
 type Boundary struct {
-	Name        string      `yaml:"name"`
-	Description string      `yaml:"description,omitempty"`
-	Tags        []string    `yaml:"tags,omitempty"`
-	Owner       string      `yaml:"owner,omitempty"`
-	Containers  []Container `yaml:"containers"`
-	Boundaries  []Boundary  `yaml:"boundaries,omitempty"`
-	Relations   []Relation  `yaml:"relations,omitempty"`
-	Meta        Metadata    `yaml:"meta,omitempty"`
+	Name        string       `yaml:"name"`
+	Description string       `yaml:"description,omitempty"`
+	Tags        []string     `yaml:"tags,omitempty"`
+	Owner       string       `yaml:"owner,omitempty"`
+	Containers  []Container  `yaml:"containers"`
+	Boundaries  []Boundary   `yaml:"boundaries,omitempty"`
+	Relations   []Relation   `yaml:"relations,omitempty"`
+	Meta        Metadata     `yaml:"meta,omitempty"`
+	Archlint    *Suppression `yaml:"archlint,omitempty"`
+
+	// Location is the source position, populated by the YAML loader.
+	Location types.Location `yaml:"-"`
 }
 
 // ContainerType enumerates supported container kinds.
@@ -41,7 +61,48 @@ type Container struct {
 	Technology  string        `yaml:"technology,omitempty"`
 	Protocol    string        `yaml:"protocol,omitempty"`
 	Tags        []string      `yaml:"tags,omitempty"`
+	Provides    []Interface   `yaml:"provides,omitempty"`
 	Meta        Metadata      `yaml:"meta,omitempty"`
+	Archlint    *Suppression  `yaml:"archlint,omitempty"`
+
+	// Location is the source position, populated by the YAML loader.
+	Location types.Location `yaml:"-"`
+}
+
+// InterfaceKind enumerates supported interface kinds.
+type InterfaceKind string
+
+const (
+	InterfaceHTTP  InterfaceKind = "http"
+	InterfaceGRPC  InterfaceKind = "grpc"
+	InterfaceTopic InterfaceKind = "topic"
+)
+
+// Interface is an operation or topic exposed by a container. Method is the
+// HTTP method of an http operation; Path is the HTTP path, the fully
+// qualified gRPC method or the topic address. An entry with Spec set is a
+// placeholder that the YAML loader replaces with the operations or channels
+// of that OpenAPI or AsyncAPI document.
+type Interface struct {
+	Name        string        `yaml:"name,omitempty"`
+	Kind        InterfaceKind `yaml:"kind,omitempty"`
+	Method      string        `yaml:"method,omitempty"`
+	Path        string        `yaml:"path,omitempty"`
+	Description string        `yaml:"description,omitempty"`
+	Spec        string        `yaml:"spec,omitempty"`
+	// Location is the source position, populated by the YAML loader.
+	// Interfaces expanded from a spec share the position of its entry.
+	Location types.Location `yaml:"-"`
+}
+
+// Interface returns the interface of c with the given name.
+func (c *Container) Interface(name string) (*Interface, bool) {
+	for i := range c.Provides {
+		if c.Provides[i].Name == name {
+			return &c.Provides[i], true
+		}
+	}
+	return nil, false
 }
 
 // RelationKind enumerates supported relation kinds.
@@ -60,6 +121,13 @@ type Relation struct {
 	Kind        RelationKind `yaml:"kind"`
 	Description string       `yaml:"description,omitempty"`
 	Protocol    string       `yaml:"protocol,omitempty"`
-	Tags        []string     `yaml:"tags,omitempty"`
-	Meta        Metadata     `yaml:"meta,omitempty"`
+	// Uses names the interface of the target container the relation calls
+	// or publishes to.
+	Uses     string       `yaml:"uses,omitempty"`
+	Tags     []string     `yaml:"tags,omitempty"`
+	Meta     Metadata     `yaml:"meta,omitempty"`
+	Archlint *Suppression `yaml:"archlint,omitempty"`
+
+	// Location is the source position, populated by the YAML loader.
+	Location types.Location `yaml:"-"`
 }
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const inputName = "<input>"
+
+// importResolver merges imported architecture files into a root model.
+type importResolver struct {
+	root       *Architecture
+	loaded     map[string]struct{}
+	stack      []string
+	display    []string
+	boundaries map[string]string
+	containers map[string]string
+}
+
+func resolveImports(arch *Architecture, file string) error {
+	r := &importResolver{
+		root:       arch,
+		loaded:     map[string]struct{}{},
+		boundaries: map[string]string{},
+		containers: map[string]string{},
+	}
+	display := file
+	if display == "" {
+		display = inputName
+	}
+	key := importKey(file)
+	r.loaded[key] = struct{}{}
+	if err := r.register(arch, display); err != nil {
+		return err
+	}
+	return r.include(arch, file, key, display)
+}
+
+// include resolves the imports declared by src, which was read from file.
+func (r *importResolver) include(src *Architecture, file, key, display string) error {
+	r.stack = append(r.stack, key)
+	r.display = append(r.display, display)
+	defer func() {
+		r.stack = r.stack[:len(r.stack)-1]
+		r.display = r.display[:len(r.display)-1]
+	}()
+
+	for _, pattern := range src.Imports {
+		paths, err := expandImport(file, pattern)
+		if err != nil {
+			return fmt.Errorf("%s: import %q: %w", display, pattern, err)
+		}
+		for _, path := range paths {
+			pathKey := importKey(path)
+			for idx, active := range r.stack {
+				if active == pathKey {
+					cycle := append(append([]string{}, r.display[idx:]...), path)
+					return fmt.Errorf("import cycle: %s", strings.Join(cycle, " -> "))
+				}
+			}
+			if _, ok := r.loaded[pathKey]; ok {
+				continue
+			}
+			r.loaded[pathKey] = struct{}{}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("%s: %w", display, err)
+			}
+			imported, err := decodeModel(data, path)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			if imported.Version != 0 && imported.Version != r.root.Version {
+				return fmt.Errorf("%s: version %d does not match importing model version %d", path, imported.Version, r.root.Version)
+			}
+			if err := r.register(imported, path); err != nil {
+				return err
+			}
+			r.merge(imported)
+			if err := r.include(imported, path, pathKey, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// register records boundary and container names declared in file and rejects
+// names already declared by another file.
+func (r *importResolver) register(arch *Architecture, file string) error {
+	var visit func(b *Boundary) error
+	visit = func(b *Boundary) error {
+		if err := claim(r.boundaries, "boundary", b.Name, file); err != nil {
+			return err
+		}
+		for i := range b.Containers {
+			if err := claim(r.containers, "container", b.Containers[i].Name, file); err != nil {
+				return err
+			}
+		}
+		for i := range b.Boundaries {
+			if err := visit(&b.Boundaries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := range arch.Boundaries {
+		if err := visit(&arch.Boundaries[i]); err != nil {
+			return err
+		}
+	}
+	for i := range arch.Externals {
+		if err := claim(r.containers, "container", arch.Externals[i].Name, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *importResolver) merge(imported *Architecture) {
+	r.root.Boundaries = append(r.root.Boundaries, imported.Boundaries...)
+	r.root.Externals = append(r.root.Externals, imported.Externals...)
+	for k, v := range imported.Meta {
+		if r.root.Meta == nil {
+			r.root.Meta = Metadata{}
+		}
+		if _, ok := r.root.Meta[k]; !ok {
+			r.root.Meta[k] = v
+		}
+	}
+}
+
+func claim(owners map[string]string, kind, name, file string) error {
+	if name == "" {
+		return nil
+	}
+	if owner, ok := owners[name]; ok && owner != file {
+		return fmt.Errorf("%s: duplicate %s name %q (already declared in %s)", file, kind, name, owner)
+	}
+	owners[name] = file
+	return nil
+}
+
+// expandImport resolves pattern relative to the importing file. Glob patterns
+// may match nothing; plain paths must exist.
+func expandImport(importer, pattern string) ([]string, error) {
+	path := pattern
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(importer), path)
+	}
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{path}, nil
+	}
+	return filepath.Glob(path)
+}
+
+func importKey(path string) string {
+	if path == "" {
+		return inputName
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
@@ -1,6 +1,10 @@
 package model
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
 
 // ContainerRef exposes discovery metadata for a container.
 type ContainerRef struct {
@@ -18,6 +22,25 @@ type RelationRef struct {
 	BoundaryPath string
 }
 
+// Location returns the container source position, or nil when unknown.
+func (r ContainerRef) Location() *types.Location {
+	return LocationPtr(r.Container.Location)
+}
+
+// InterfaceLocation returns the source position of the container's i-th
+// interface, falling back to the container for interfaces built in code.
+func (r ContainerRef) InterfaceLocation(i int) *types.Location {
+	if loc := LocationPtr(r.Container.Provides[i].Location); loc != nil {
+		return loc
+	}
+	return r.Location()
+}
+
+// Location returns the relation source position, or nil when unknown.
+func (r RelationRef) Location() *types.Location {
+	return LocationPtr(r.Relation.Location)
+}
+
 // Containers returns every container declared in the architecture, including externals.
 func (a *Architecture) Containers() []ContainerRef {
 	refs := make([]ContainerRef, 0)
@@ -81,3 +104,53 @@ func gatherBoundaryRelations(dst *[]RelationRef, b *Boundary, path string) {
 		gatherBoundaryRelations(dst, &b.Boundaries[i], fmt.Sprintf("%s.boundaries[%d]", path, i))
 	}
 }
+
+// SuppressionRef exposes an inline suppression and the element it annotates.
+type SuppressionRef struct {
+	Suppression *Suppression
+	Path        string
+	Location    types.Location
+}
+
+// Suppressions returns every inline suppression in declaration order.
+func (a *Architecture) Suppressions() []SuppressionRef {
+	refs := make([]SuppressionRef, 0)
+	for i := range a.Boundaries {
+		gatherBoundarySuppressions(&refs, &a.Boundaries[i], fmt.Sprintf("boundaries[%d]", i))
+	}
+	for i := range a.Externals {
+		appendSuppression(&refs, a.Externals[i].Archlint, fmt.Sprintf("externals[%d]", i), a.Externals[i].Location)
+	}
+	return refs
+}
+
+func gatherBoundarySuppressions(dst *[]SuppressionRef, b *Boundary, path string) {
+	appendSuppression(dst, b.Archlint, path, b.Location)
+	for i := range b.Containers {
+		c := &b.Containers[i]
+		appendSuppression(dst, c.Archlint, fmt.Sprintf("%s.containers[%d]", path, i), c.Location)
+	}
+	for i := range b.Relations {
+		r := &b.Relations[i]
+		appendSuppression(dst, r.Archlint, fmt.Sprintf("%s.relations[%d]", path, i), r.Location)
+	}
+	for i := range b.Boundaries {
+		gatherBoundarySuppressions(dst, &b.Boundaries[i], fmt.Sprintf("%s.boundaries[%d]", path, i))
+	}
+}
+
+func appendSuppression(dst *[]SuppressionRef, s *Suppression, path string, loc types.Location) {
+	if s == nil {
+		return
+	}
+	*dst = append(*dst, SuppressionRef{Suppression: s, Path: path, Location: loc})
+}
+
+// LocationPtr returns a pointer to loc, or nil when the position is unknown,
+// as expected by types.Finding.Location.
+func LocationPtr(loc types.Location) *types.Location {
+	if loc.IsZero() {
+		return nil
+	}
+	return &loc
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StableKey rewrites an index-based finding path such as
+// boundaries[0].relations[2].protocol into a key built from names, e.g.
+// relation:api->audit[sync].protocol, and provided interfaces as
+// container:orders.provides:createOrder, so it survives reordering of
+// boundaries, containers and relations. Paths that do not address a model
+// element are returned unchanged.
+func (a *Architecture) StableKey(path string) string {
+	segments := strings.Split(path, ".")
+	key := ""
+	var boundary *Boundary
+	var container *Container
+	boundaryNames := make([]string, 0)
+
+	for i, seg := range segments {
+		field, idx, ok := parseIndexSegment(seg)
+		if !ok {
+			if key == "" {
+				return path
+			}
+			return key + "." + strings.Join(segments[i:], ".")
+		}
+		switch {
+		case field == "boundaries" && boundary == nil && i == 0:
+			if idx >= len(a.Boundaries) {
+				return path
+			}
+			boundary = &a.Boundaries[idx]
+		case field == "boundaries" && boundary != nil:
+			if idx >= len(boundary.Boundaries) {
+				return path
+			}
+			boundary = &boundary.Boundaries[idx]
+		case field == "containers" && boundary != nil:
+			if idx >= len(boundary.Containers) {
+				return path
+			}
+			container = &boundary.Containers[idx]
+			key = "container:" + container.Name
+			boundary = nil
+		case field == "relations" && boundary != nil:
+			if idx >= len(boundary.Relations) {
+				return path
+			}
+			rel := boundary.Relations[idx]
+			key = fmt.Sprintf("relation:%s->%s[%s]", rel.From, rel.To, rel.Kind)
+			boundary = nil
+		case field == "externals" && i == 0:
+			if idx >= len(a.Externals) {
+				return path
+			}
+			container = &a.Externals[idx]
+			key = "container:" + container.Name
+		case field == "provides" && container != nil:
+			if idx >= len(container.Provides) {
+				return path
+			}
+			key += ".provides:" + container.Provides[idx].Name
+			container = nil
+		default:
+			return path
+		}
+		if boundary != nil {
+			boundaryNames = append(boundaryNames, boundary.Name)
+			key = "boundary:" + strings.Join(boundaryNames, "/")
+		}
+	}
+	if key == "" {
+		return path
+	}
+	return key
+}
+
+func parseIndexSegment(seg string) (string, int, bool) {
+	open := strings.IndexByte(seg, '[')
+	if open <= 0 || !strings.HasSuffix(seg, "]") {
+		return "", 0, false
+	}
+	idx, err := strconv.Atoi(seg[open+1 : len(seg)-1])
+	if err != nil || idx < 0 {
+		return "", 0, false
+	}
+	return seg[:open], idx, true
+}
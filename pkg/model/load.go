@@ -1,18 +1,167 @@
 package model
 
 import (
+	"bytes"
 	"io"
+	"os"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-// LoadModelFromYAML parses an architecture definition from YAML.
+// LoadModelFromYAML parses an architecture definition from YAML. Imports and
+// interface specs are resolved relative to the current working directory.
 func LoadModelFromYAML(r io.Reader) (*Architecture, error) {
-	dec := yaml.NewDecoder(r)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	arch, err := decodeModel(data, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveImports(arch, ""); err != nil {
+		return nil, err
+	}
+	return arch, nil
+}
+
+// LoadModelFromFile parses an architecture definition from a YAML file and
+// records the file name on every source location. Imports and interface specs
+// are resolved relative to the file that references them.
+func LoadModelFromFile(path string) (*Architecture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	arch, err := decodeModel(data, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveImports(arch, path); err != nil {
+		return nil, err
+	}
+	return arch, nil
+}
+
+func decodeModel(data []byte, file string) (*Architecture, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
 	dec.KnownFields(true)
 	var arch Architecture
 	if err := dec.Decode(&arch); err != nil {
 		return nil, err
 	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	annotateArchitecture(&arch, &doc, file)
+	if err := expandSpecs(&arch, file); err != nil {
+		return nil, err
+	}
 	return &arch, nil
 }
+
+func annotateArchitecture(arch *Architecture, doc *yaml.Node, file string) {
+	root := resolveNode(doc)
+	if root == nil {
+		return
+	}
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return
+		}
+		root = resolveNode(root.Content[0])
+	}
+	arch.Location = nodeLocation(root, file)
+
+	for i, item := range sequenceItems(mappingValue(root, "boundaries")) {
+		if i < len(arch.Boundaries) {
+			annotateBoundary(&arch.Boundaries[i], item, file)
+		}
+	}
+	for i, item := range sequenceItems(mappingValue(root, "externals")) {
+		if i < len(arch.Externals) {
+			annotateContainer(&arch.Externals[i], item, file)
+		}
+	}
+}
+
+func annotateBoundary(b *Boundary, node *yaml.Node, file string) {
+	b.Location = nodeLocation(node, file)
+	for i, item := range sequenceItems(mappingValue(node, "containers")) {
+		if i < len(b.Containers) {
+			annotateContainer(&b.Containers[i], item, file)
+		}
+	}
+	for i, item := range sequenceItems(mappingValue(node, "relations")) {
+		if i < len(b.Relations) {
+			b.Relations[i].Location = nodeLocation(item, file)
+		}
+	}
+	for i, item := range sequenceItems(mappingValue(node, "boundaries")) {
+		if i < len(b.Boundaries) {
+			annotateBoundary(&b.Boundaries[i], item, file)
+		}
+	}
+}
+
+func annotateContainer(c *Container, node *yaml.Node, file string) {
+	c.Location = nodeLocation(node, file)
+	for i, item := range sequenceItems(mappingValue(node, "provides")) {
+		if i < len(c.Provides) {
+			c.Provides[i].Location = nodeLocation(item, file)
+		}
+	}
+}
+
+func resolveNode(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveNode(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveNode(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	node = resolveNode(node)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	items := make([]*yaml.Node, len(node.Content))
+	for i, item := range node.Content {
+		items[i] = resolveNode(item)
+	}
+	return items
+}
+
+func nodeLocation(node *yaml.Node, file string) types.Location {
+	if node == nil {
+		return types.Location{}
+	}
+	return types.Location{File: file, Line: node.Line, Column: node.Column}
+}
+
+// WriteYAML serializes an architecture definition back to YAML.
+func WriteYAML(w io.Writer, m *Architecture) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+	return enc.Close()
+}
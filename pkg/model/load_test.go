@@ -0,0 +1,198 @@
+package model_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+func TestLoadModelFromFileLocations(t *testing.T) {
+	path := filepath.Join("..", "..", "testdata", "arch_acl_violation.yaml")
+	m, err := model.LoadModelFromFile(path)
+	if err != nil {
+		t.Fatalf("load yaml: %v", err)
+	}
+	b := m.Boundaries[0]
+	if b.Location.File != path || b.Location.Line != 3 || b.Location.Column != 5 {
+		t.Fatalf("unexpected boundary location: %+v", b.Location)
+	}
+	if loc := b.Relations[2].Location; loc.Line != 19 || loc.Column != 9 {
+		t.Fatalf("unexpected relation location: %+v", loc)
+	}
+	if loc := m.Externals[0].Location; loc.Line != 24 {
+		t.Fatalf("unexpected external location: %+v", loc)
+	}
+
+	m.Boundaries[0].Relations[2].To = "missing"
+	findings := model.ValidateModel(m)
+	if len(findings) != 1 || findings[0].Location == nil || findings[0].Location.Line != 19 {
+		t.Fatalf("expected located finding, got %+v", findings)
+	}
+}
+
+func TestLoadModelFromFileImports(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "root.yaml", `
+version: 1
+imports: [teams/*.yaml]
+boundaries:
+  - name: Edge
+    containers:
+      - name: gateway
+        type: service
+    relations:
+      - from: gateway
+        to: payments-api
+        kind: sync
+`)
+	writeFile(t, dir, "teams/payments.yaml", `
+imports: [../shared.yaml]
+boundaries:
+  - name: Payments
+    containers:
+      - name: payments-api
+        type: service
+    relations:
+      - from: payments-api
+        to: ledger
+        kind: sync
+`)
+	writeFile(t, dir, "shared.yaml", `
+version: 1
+boundaries:
+  - name: Ledger
+    containers:
+      - name: ledger
+        type: service
+`)
+
+	m, err := model.LoadModelFromFile(filepath.Join(dir, "root.yaml"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(m.Boundaries) != 3 {
+		t.Fatalf("expected merged boundaries, got %+v", m.Boundaries)
+	}
+	if findings := model.ValidateModel(m); len(findings) != 0 {
+		t.Fatalf("expected merged model to validate, got %v", findings)
+	}
+	for _, ref := range m.Relations() {
+		if ref.Relation.From == "payments-api" && !strings.HasSuffix(ref.Relation.Location.File, filepath.Join("teams", "payments.yaml")) {
+			t.Fatalf("expected relation to keep its source file, got %+v", ref.Relation.Location)
+		}
+	}
+}
+
+func TestLoadModelFromFileImportErrors(t *testing.T) {
+	t.Run("cycle", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "a.yaml", "version: 1\nimports: [b.yaml]\nboundaries: []\n")
+		writeFile(t, dir, "b.yaml", "imports: [a.yaml]\nboundaries: []\n")
+		_, err := model.LoadModelFromFile(filepath.Join(dir, "a.yaml"))
+		if err == nil || !strings.Contains(err.Error(), "import cycle") {
+			t.Fatalf("expected import cycle error, got %v", err)
+		}
+	})
+
+	t.Run("duplicate container", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "a.yaml", `
+version: 1
+imports: [b.yaml]
+boundaries:
+  - name: A
+    containers:
+      - name: api
+        type: service
+`)
+		writeFile(t, dir, "b.yaml", `
+boundaries:
+  - name: B
+    containers:
+      - name: api
+        type: service
+`)
+		_, err := model.LoadModelFromFile(filepath.Join(dir, "a.yaml"))
+		if err == nil || !strings.Contains(err.Error(), `duplicate container name "api"`) || !strings.Contains(err.Error(), "a.yaml") {
+			t.Fatalf("expected duplicate container error naming both files, got %v", err)
+		}
+	})
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write temp: %v", err)
+	}
+}
+
+func TestLoadModelFromFileSpecs(t *testing.T) {
+	m, err := model.LoadModelFromFile(filepath.Join("..", "..", "testdata", "arch_interfaces.yaml"))
+	if err != nil {
+		t.Fatalf("load yaml: %v", err)
+	}
+	orders := m.Boundaries[0].Containers[1]
+	if len(orders.Provides) != 3 {
+		t.Fatalf("expected two OpenAPI operations and one inline interface, got %+v", orders.Provides)
+	}
+	if got := orders.Provides[0]; got.Name != "createOrder" || got.Kind != model.InterfaceHTTP || got.Method != "POST" || got.Path != "/orders" {
+		t.Fatalf("unexpected operation with operationId: %+v", got)
+	}
+	if got := orders.Provides[1]; got.Name != "GET /orders/{id}" || got.Description != "Fetch an order" {
+		t.Fatalf("unexpected operation without operationId: %+v", got)
+	}
+	if orders.Provides[1].Location.Line != 13 || orders.Provides[2].Location.Line != 14 {
+		t.Fatalf("expected interfaces to point at their provides entries, got %v and %v", orders.Provides[1].Location, orders.Provides[2].Location)
+	}
+	broker := m.Boundaries[0].Containers[2]
+	if len(broker.Provides) != 2 || broker.Provides[0].Name != "orderCancelled" || broker.Provides[0].Kind != model.InterfaceTopic || broker.Provides[0].Path != "orders.cancelled" {
+		t.Fatalf("unexpected AsyncAPI channels: %+v", broker.Provides)
+	}
+	if findings := model.ValidateModel(m); len(findings) != 0 {
+		t.Fatalf("expected no findings, got %v", findings)
+	}
+
+	t.Run("missing spec", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "arch.yaml", `
+version: 1
+boundaries:
+  - name: A
+    containers:
+      - name: api
+        type: service
+        provides:
+          - spec: api/openapi.yaml
+`)
+		_, err := model.LoadModelFromFile(filepath.Join(dir, "arch.yaml"))
+		if err == nil || !strings.Contains(err.Error(), `arch.yaml:9:13: container "api": spec api/openapi.yaml`) {
+			t.Fatalf("expected spec error naming the entry and container, got %v", err)
+		}
+	})
+
+	t.Run("unknown document", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "spec.json", `{"swagger2": "2.0"}`)
+		writeFile(t, dir, "arch.yaml", `
+version: 1
+boundaries:
+  - name: A
+    containers:
+      - name: api
+        type: service
+        provides:
+          - spec: spec.json
+`)
+		_, err := model.LoadModelFromFile(filepath.Join(dir, "arch.yaml"))
+		if err == nil || !strings.Contains(err.Error(), "not an OpenAPI or AsyncAPI document") {
+			t.Fatalf("expected unknown document error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// httpMethods lists the OpenAPI path item keys that declare operations, in
+// the order operations are emitted.
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// specDocument covers the parts of OpenAPI (2 and 3) and AsyncAPI (2 and 3)
+// documents that describe provided interfaces.
+type specDocument struct {
+	OpenAPI  string                          `yaml:"openapi"`
+	Swagger  string                          `yaml:"swagger"`
+	AsyncAPI string                          `yaml:"asyncapi"`
+	Paths    map[string]map[string]yaml.Node `yaml:"paths"`
+	Channels map[string]specChannel          `yaml:"channels"`
+}
+
+type specOperation struct {
+	OperationID string `yaml:"operationId"`
+	Summary     string `yaml:"summary"`
+}
+
+type specChannel struct {
+	Address     string `yaml:"address"`
+	Description string `yaml:"description"`
+}
+
+// expandSpecs replaces every provides entry that references a spec document
+// with the interfaces declared in it. Spec paths are resolved relative to
+// file, or to the working directory when file is empty.
+func expandSpecs(arch *Architecture, file string) error {
+	var visit func(b *Boundary) error
+	visit = func(b *Boundary) error {
+		for i := range b.Containers {
+			if err := expandContainerSpecs(&b.Containers[i], file); err != nil {
+				return err
+			}
+		}
+		for i := range b.Boundaries {
+			if err := visit(&b.Boundaries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := range arch.Boundaries {
+		if err := visit(&arch.Boundaries[i]); err != nil {
+			return err
+		}
+	}
+	for i := range arch.Externals {
+		if err := expandContainerSpecs(&arch.Externals[i], file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandContainerSpecs(c *Container, file string) error {
+	expanded := make([]Interface, 0, len(c.Provides))
+	changed := false
+	for _, iface := range c.Provides {
+		if iface.Spec == "" {
+			expanded = append(expanded, iface)
+			continue
+		}
+		path := iface.Spec
+		if !filepath.IsAbs(path) && file != "" {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+		loaded, err := loadSpec(path)
+		if err != nil {
+			err = fmt.Errorf("container %q: spec %s: %w", c.Name, iface.Spec, err)
+			if !iface.Location.IsZero() {
+				err = fmt.Errorf("%s: %w", iface.Location, err)
+			}
+			return err
+		}
+		for i := range loaded {
+			loaded[i].Location = iface.Location
+		}
+		expanded = append(expanded, loaded...)
+		changed = true
+	}
+	if changed {
+		c.Provides = expanded
+	}
+	return nil
+}
+
+// loadSpec reads the interfaces declared by an OpenAPI or AsyncAPI document
+// in YAML or JSON.
+func loadSpec(path string) ([]Interface, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc specDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	switch {
+	case doc.OpenAPI != "" || doc.Swagger != "":
+		return openAPIInterfaces(doc)
+	case doc.AsyncAPI != "":
+		return asyncAPIInterfaces(doc), nil
+	default:
+		return nil, errors.New("not an OpenAPI or AsyncAPI document")
+	}
+}
+
+func openAPIInterfaces(doc specDocument) ([]Interface, error) {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	out := make([]Interface, 0)
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, method := range httpMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op specOperation
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("paths.%s.%s: %w", path, method, err)
+			}
+			name := op.OperationID
+			if name == "" {
+				name = strings.ToUpper(method) + " " + path
+			}
+			out = append(out, Interface{
+				Name:        name,
+				Kind:        InterfaceHTTP,
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				Description: op.Summary,
+			})
+		}
+	}
+	return out, nil
+}
+
+func asyncAPIInterfaces(doc specDocument) []Interface {
+	names := make([]string, 0, len(doc.Channels))
+	for name := range doc.Channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]Interface, 0, len(names))
+	for _, name := range names {
+		channel := doc.Channels[name]
+		address := channel.Address
+		if address == "" {
+			address = name
+		}
+		out = append(out, Interface{
+			Name:        name,
+			Kind:        InterfaceTopic,
+			Path:        address,
+			Description: channel.Description,
+		})
+	}
+	return out
+}
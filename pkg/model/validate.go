@@ -9,6 +9,17 @@ import (
 
 const validationRuleID = "MODEL-0001"
 
+// ValidationRuleMetadata describes the structural validation pseudo-rule.
+func ValidationRuleMetadata() types.RuleMetadata {
+	return types.RuleMetadata{
+		ID:              validationRuleID,
+		Description:     "The architecture document must be structurally valid.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "Rules can only reason about a model whose names resolve and whose version is understood.",
+		DocURL:          types.RuleDocURL(validationRuleID),
+	}
+}
+
 // ValidateModel performs structural validation and returns findings.
 func ValidateModel(m *Architecture) []types.Finding {
 	if m == nil {
@@ -28,6 +39,7 @@ func ValidateModel(m *Architecture) []types.Finding {
 			Severity: types.SeverityError,
 			Message:  fmt.Sprintf("unsupported version %d (only version 1 is supported)", m.Version),
 			Path:     "version",
+			Location: LocationPtr(m.Location),
 		})
 	}
 
@@ -37,11 +49,13 @@ func ValidateModel(m *Architecture) []types.Finding {
 			Severity: types.SeverityError,
 			Message:  "at least one boundary is required",
 			Path:     "boundaries",
+			Location: LocationPtr(m.Location),
 		})
 	}
 
 	containers := m.Containers()
 	nameIndex := map[string]string{}
+	refIndex := map[string]ContainerRef{}
 	for _, ref := range containers {
 		c := ref.Container
 		if strings.TrimSpace(c.Name) == "" {
@@ -50,6 +64,7 @@ func ValidateModel(m *Architecture) []types.Finding {
 				Severity: types.SeverityError,
 				Message:  "container name is required",
 				Path:     ref.Path + ".name",
+				Location: ref.Location(),
 			})
 		}
 		if _, ok := nameIndex[c.Name]; ok {
@@ -58,12 +73,14 @@ func ValidateModel(m *Architecture) []types.Finding {
 				Severity: types.SeverityError,
 				Message:  fmt.Sprintf("duplicate container name %q", c.Name),
 				Path:     ref.Path + ".name",
+				Location: ref.Location(),
 				Meta: map[string]any{
 					"container": c.Name,
 				},
 			})
 		} else if c.Name != "" {
 			nameIndex[c.Name] = ref.Path
+			refIndex[c.Name] = ref
 		}
 
 		switch c.Type {
@@ -74,8 +91,10 @@ func ValidateModel(m *Architecture) []types.Finding {
 				Severity: types.SeverityError,
 				Message:  fmt.Sprintf("invalid container type %q", c.Type),
 				Path:     ref.Path + ".type",
+				Location: ref.Location(),
 			})
 		}
+		findings = append(findings, validateInterfaces(ref)...)
 	}
 
 	relations := m.Relations()
@@ -87,6 +106,7 @@ func ValidateModel(m *Architecture) []types.Finding {
 				Severity: types.SeverityError,
 				Message:  "relation must define both from and to",
 				Path:     ref.Path,
+				Location: ref.Location(),
 			})
 			continue
 		}
@@ -96,6 +116,7 @@ func ValidateModel(m *Architecture) []types.Finding {
 				Severity: types.SeverityError,
 				Message:  fmt.Sprintf("relation references unknown container %q", rel.From),
 				Path:     ref.Path + ".from",
+				Location: ref.Location(),
 			})
 		}
 		if _, ok := nameIndex[rel.To]; !ok {
@@ -104,6 +125,7 @@ func ValidateModel(m *Architecture) []types.Finding {
 				Severity: types.SeverityError,
 				Message:  fmt.Sprintf("relation references unknown container %q", rel.To),
 				Path:     ref.Path + ".to",
+				Location: ref.Location(),
 			})
 		}
 		switch rel.Kind {
@@ -114,9 +136,103 @@ func ValidateModel(m *Architecture) []types.Finding {
 				Severity: types.SeverityError,
 				Message:  fmt.Sprintf("invalid relation kind %q", rel.Kind),
 				Path:     ref.Path + ".kind",
+				Location: ref.Location(),
 			})
 		}
+		if rel.Uses != "" {
+			if target, ok := refIndex[rel.To]; ok {
+				findings = append(findings, validateUses(ref, target)...)
+			}
+		}
 	}
 
 	return findings
 }
+
+func validateInterfaces(ref ContainerRef) []types.Finding {
+	findings := make([]types.Finding, 0)
+	seen := map[string]struct{}{}
+	for i, iface := range ref.Container.Provides {
+		path := fmt.Sprintf("%s.provides[%d]", ref.Path, i)
+		location := ref.InterfaceLocation(i)
+		if iface.Spec != "" {
+			findings = append(findings, types.Finding{
+				RuleID:   validationRuleID,
+				Severity: types.SeverityError,
+				Message:  fmt.Sprintf("interface spec %q was not loaded", iface.Spec),
+				Path:     path + ".spec",
+				Location: location,
+			})
+			continue
+		}
+		if strings.TrimSpace(iface.Name) == "" {
+			findings = append(findings, types.Finding{
+				RuleID:   validationRuleID,
+				Severity: types.SeverityError,
+				Message:  "interface name is required",
+				Path:     path + ".name",
+				Location: location,
+			})
+		} else if _, ok := seen[iface.Name]; ok {
+			findings = append(findings, types.Finding{
+				RuleID:   validationRuleID,
+				Severity: types.SeverityError,
+				Message:  fmt.Sprintf("duplicate interface name %q in container %q", iface.Name, ref.Container.Name),
+				Path:     path + ".name",
+				Location: location,
+			})
+		} else {
+			seen[iface.Name] = struct{}{}
+		}
+		switch iface.Kind {
+		case InterfaceHTTP, InterfaceGRPC, InterfaceTopic:
+		default:
+			findings = append(findings, types.Finding{
+				RuleID:   validationRuleID,
+				Severity: types.SeverityError,
+				Message:  fmt.Sprintf("invalid interface kind %q", iface.Kind),
+				Path:     path + ".kind",
+				Location: location,
+			})
+		}
+	}
+	return findings
+}
+
+// validateUses checks that the interface a relation uses is provided by its
+// target and matches the relation kind.
+func validateUses(ref RelationRef, target ContainerRef) []types.Finding {
+	rel := ref.Relation
+	iface, ok := target.Container.Interface(rel.Uses)
+	if !ok {
+		return []types.Finding{{
+			RuleID:   validationRuleID,
+			Severity: types.SeverityError,
+			Message:  fmt.Sprintf("relation uses unknown interface %q of container %q", rel.Uses, rel.To),
+			Path:     ref.Path + ".uses",
+			Location: ref.Location(),
+		}}
+	}
+	var allowed bool
+	switch rel.Kind {
+	case RelationKindSync:
+		allowed = iface.Kind == InterfaceHTTP || iface.Kind == InterfaceGRPC
+	case RelationKindAsync:
+		allowed = iface.Kind == InterfaceTopic
+	case RelationKindDB:
+		// Databases are accessed directly, never through an interface.
+	default:
+		// Invalid kinds are already reported.
+		return nil
+	}
+	if allowed {
+		return nil
+	}
+	return []types.Finding{{
+		RuleID:   validationRuleID,
+		Severity: types.SeverityError,
+		Message:  fmt.Sprintf("%s relation cannot use %s interface %q of container %q", rel.Kind, iface.Kind, rel.Uses, rel.To),
+		Path:     ref.Path + ".uses",
+		Location: ref.Location(),
+	}}
+}
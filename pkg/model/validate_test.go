@@ -38,6 +38,49 @@ func TestValidateModel(t *testing.T) {
 			t.Fatalf("expected at least 2 findings, got %v", findings)
 		}
 	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		m := &model.Architecture{
+			Version: 1,
+			Boundaries: []model.Boundary{{
+				Name: "shop",
+				Containers: []model.Container{
+					{Name: "api", Type: model.ContainerService},
+					{Name: "orders", Type: model.ContainerService, Provides: []model.Interface{
+						{Name: "createOrder", Kind: model.InterfaceHTTP},
+						{Name: "createOrder", Kind: model.InterfaceGRPC},
+						{Name: "order.created", Kind: model.InterfaceTopic},
+						{Name: "legacy", Kind: "soap"},
+					}},
+				},
+				Relations: []model.Relation{
+					{From: "api", To: "orders", Kind: model.RelationKindSync, Uses: "createOrder"},
+					{From: "api", To: "orders", Kind: model.RelationKindSync, Uses: "order.created"},
+					{From: "api", To: "orders", Kind: model.RelationKindAsync, Uses: "createOrder"},
+					{From: "api", To: "orders", Kind: model.RelationKindSync, Uses: "deleteOrder"},
+				},
+			}},
+		}
+		got := map[string]string{}
+		for _, f := range model.ValidateModel(m) {
+			got[f.Path] = f.Message
+		}
+		want := map[string]string{
+			"boundaries[0].containers[1].provides[1].name": `duplicate interface name "createOrder" in container "orders"`,
+			"boundaries[0].containers[1].provides[3].kind": `invalid interface kind "soap"`,
+			"boundaries[0].relations[1].uses":              `sync relation cannot use topic interface "order.created" of container "orders"`,
+			"boundaries[0].relations[2].uses":              `async relation cannot use http interface "createOrder" of container "orders"`,
+			"boundaries[0].relations[3].uses":              `relation uses unknown interface "deleteOrder" of container "orders"`,
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected %d findings, got %v", len(want), got)
+		}
+		for path, msg := range want {
+			if got[path] != msg {
+				t.Fatalf("finding at %s = %q, want %q (all: %v)", path, got[path], msg, got)
+			}
+		}
+	})
 }
 
 func mustLoadModel(t *testing.T, rel string) *model.Architecture {
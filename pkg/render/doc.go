@@ -0,0 +1,5 @@
+// Package render draws architecture models as diagrams. Boundaries become
+// clusters (respecting nesting), containers are styled by type and relations
+// are labeled with their kind and protocol. Findings can be passed in to
+// highlight the offending elements.
+package render
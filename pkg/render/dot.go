@@ -0,0 +1,79 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// WriteDOT renders m as a Graphviz digraph with one cluster per boundary.
+func WriteDOT(w io.Writer, m *model.Architecture, opts Options) error {
+	h := collectHighlights(m, opts.Findings)
+	ids := newIDSet()
+	lw := &lineWriter{w: w}
+
+	lw.printf(0, "digraph architecture {")
+	lw.printf(1, "rankdir=LR;")
+	lw.printf(1, "compound=true;")
+	lw.printf(1, `node [fontname="Helvetica"];`)
+	lw.printf(1, `edge [fontname="Helvetica", fontsize=10];`)
+
+	var boundary func(b *model.Boundary, depth int)
+	boundary = func(b *model.Boundary, depth int) {
+		lw.printf(depth, "subgraph %s {", ids.id("cluster_", b.Name))
+		lw.printf(depth+1, "label=%s;", dotQuote(b.Name))
+		if h.boundaries[b] {
+			lw.printf(depth+1, `color="red";`)
+			lw.printf(depth+1, "penwidth=2;")
+		}
+		for i := range b.Containers {
+			dotContainer(lw, depth+1, &b.Containers[i], h)
+		}
+		for i := range b.Boundaries {
+			boundary(&b.Boundaries[i], depth+1)
+		}
+		lw.printf(depth, "}")
+	}
+	for i := range m.Boundaries {
+		boundary(&m.Boundaries[i], 1)
+	}
+	for i := range m.Externals {
+		dotContainer(lw, 1, &m.Externals[i], h)
+	}
+
+	for _, ref := range m.Relations() {
+		rel := ref.Relation
+		attrs := []string{"label=" + dotQuote(relationLabel(rel))}
+		if rel.Kind == model.RelationKindAsync {
+			attrs = append(attrs, "style=dashed")
+		}
+		if h.relations[rel] {
+			attrs = append(attrs, `color="red"`, `fontcolor="red"`, "penwidth=2")
+		}
+		lw.printf(1, "%s -> %s [%s];", dotQuote(rel.From), dotQuote(rel.To), strings.Join(attrs, ", "))
+	}
+	lw.printf(0, "}")
+	return lw.err
+}
+
+func dotContainer(lw *lineWriter, depth int, c *model.Container, h highlights) {
+	attrs := []string{"label=" + dotQuote(c.Name)}
+	switch c.Type {
+	case model.ContainerDatabase:
+		attrs = append(attrs, "shape=cylinder")
+	case model.ContainerExternal:
+		attrs = append(attrs, "shape=box", `style="dashed"`)
+	default:
+		attrs = append(attrs, "shape=box", `style="rounded"`)
+	}
+	if h.containers[c.Name] {
+		attrs = append(attrs, `color="red"`, "penwidth=2")
+	}
+	lw.printf(depth, "%s [%s];", dotQuote(c.Name), strings.Join(attrs, ", "))
+}
+
+func dotQuote(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s))
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// WriteMermaid renders m as a Mermaid flowchart with one subgraph per boundary.
+func WriteMermaid(w io.Writer, m *model.Architecture, opts Options) error {
+	h := collectHighlights(m, opts.Findings)
+	ids := newIDSet()
+	lw := &lineWriter{w: w}
+
+	lw.printf(0, "flowchart LR")
+	var boundary func(b *model.Boundary, depth int)
+	boundary = func(b *model.Boundary, depth int) {
+		id := ids.id("b_", b.Name)
+		lw.printf(depth, "subgraph %s [%s]", id, mermaidQuote(b.Name))
+		for i := range b.Containers {
+			mermaidContainer(lw, depth+1, ids, &b.Containers[i])
+		}
+		for i := range b.Boundaries {
+			boundary(&b.Boundaries[i], depth+1)
+		}
+		lw.printf(depth, "end")
+		if h.boundaries[b] {
+			lw.printf(depth, "style %s stroke:#d33,stroke-width:3px", id)
+		}
+	}
+	for i := range m.Boundaries {
+		boundary(&m.Boundaries[i], 1)
+	}
+	for i := range m.Externals {
+		mermaidContainer(lw, 1, ids, &m.Externals[i])
+	}
+
+	highlighted := make([]string, 0)
+	for idx, ref := range m.Relations() {
+		rel := ref.Relation
+		arrow := "-->"
+		if rel.Kind == model.RelationKindAsync {
+			arrow = "-.->"
+		}
+		lw.printf(1, "%s %s|%s| %s", ids.id("c_", rel.From), arrow, mermaidQuote(relationLabel(rel)), ids.id("c_", rel.To))
+		if h.relations[rel] {
+			highlighted = append(highlighted, fmt.Sprint(idx))
+		}
+	}
+
+	lw.printf(1, "classDef service fill:#e8f0fe,stroke:#4a6fa5")
+	lw.printf(1, "classDef database fill:#fef7e0,stroke:#b08800")
+	lw.printf(1, "classDef external fill:#f1f3f4,stroke:#80868b,stroke-dasharray:4 3")
+	lw.printf(1, "classDef violation stroke:#d33,stroke-width:3px")
+	for _, ref := range m.Containers() {
+		if h.containers[ref.Container.Name] {
+			lw.printf(1, "class %s violation", ids.id("c_", ref.Container.Name))
+		}
+	}
+	if len(highlighted) > 0 {
+		lw.printf(1, "linkStyle %s stroke:#d33,stroke-width:3px", strings.Join(highlighted, ","))
+	}
+	return lw.err
+}
+
+func mermaidContainer(lw *lineWriter, depth int, ids *idSet, c *model.Container) {
+	id := ids.id("c_", c.Name)
+	label := mermaidQuote(c.Name)
+	switch c.Type {
+	case model.ContainerDatabase:
+		lw.printf(depth, "%s[(%s)]:::database", id, label)
+	case model.ContainerExternal:
+		lw.printf(depth, "%s[[%s]]:::external", id, label)
+	default:
+		lw.printf(depth, "%s(%s):::service", id, label)
+	}
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,77 @@
+package render
+
+import (
+	"io"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// WritePlantUML renders m as a PlantUML component diagram with one rectangle
+// per boundary.
+func WritePlantUML(w io.Writer, m *model.Architecture, opts Options) error {
+	h := collectHighlights(m, opts.Findings)
+	ids := newIDSet()
+	lw := &lineWriter{w: w}
+
+	lw.printf(0, "@startuml")
+	lw.printf(0, "left to right direction")
+	var boundary func(b *model.Boundary, depth int)
+	boundary = func(b *model.Boundary, depth int) {
+		style := ""
+		if h.boundaries[b] {
+			style = " #line:red;line.bold"
+		}
+		lw.printf(depth, "rectangle %s as %s%s {", plantQuote(b.Name), ids.id("b_", b.Name), style)
+		for i := range b.Containers {
+			plantContainer(lw, depth+1, ids, &b.Containers[i], h)
+		}
+		for i := range b.Boundaries {
+			boundary(&b.Boundaries[i], depth+1)
+		}
+		lw.printf(depth, "}")
+	}
+	for i := range m.Boundaries {
+		boundary(&m.Boundaries[i], 0)
+	}
+	for i := range m.Externals {
+		plantContainer(lw, 0, ids, &m.Externals[i], h)
+	}
+
+	for _, ref := range m.Relations() {
+		rel := ref.Relation
+		styles := make([]string, 0, 3)
+		if h.relations[rel] {
+			styles = append(styles, "#red", "bold")
+		}
+		if rel.Kind == model.RelationKindAsync {
+			styles = append(styles, "dashed")
+		}
+		arrow := "-->"
+		if len(styles) > 0 {
+			arrow = "-[" + strings.Join(styles, ",") + "]->"
+		}
+		lw.printf(0, "%s %s %s : %s", ids.id("c_", rel.From), arrow, ids.id("c_", rel.To), relationLabel(rel))
+	}
+	lw.printf(0, "@enduml")
+	return lw.err
+}
+
+func plantContainer(lw *lineWriter, depth int, ids *idSet, c *model.Container, h highlights) {
+	keyword := "component"
+	switch c.Type {
+	case model.ContainerDatabase:
+		keyword = "database"
+	case model.ContainerExternal:
+		keyword = "cloud"
+	}
+	style := ""
+	if h.containers[c.Name] {
+		style = " #line:red;line.bold"
+	}
+	lw.printf(depth, "%s %s as %s%s", keyword, plantQuote(c.Name), ids.id("c_", c.Name), style)
+}
+
+func plantQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
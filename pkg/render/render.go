@@ -0,0 +1,162 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// Format names a supported diagram syntax.
+type Format string
+
+const (
+	FormatDOT      Format = "dot"
+	FormatMermaid  Format = "mermaid"
+	FormatPlantUML Format = "plantuml"
+)
+
+// Options tune diagram rendering.
+type Options struct {
+	// Findings highlights the containers, relations and boundaries they point at.
+	Findings []types.Finding
+}
+
+// Write renders m in the requested format.
+func Write(w io.Writer, format Format, m *model.Architecture, opts Options) error {
+	switch format {
+	case FormatDOT:
+		return WriteDOT(w, m, opts)
+	case FormatMermaid:
+		return WriteMermaid(w, m, opts)
+	case FormatPlantUML:
+		return WritePlantUML(w, m, opts)
+	default:
+		return fmt.Errorf("unknown graph format %s", format)
+	}
+}
+
+// highlights indexes model elements referenced by findings.
+type highlights struct {
+	containers map[string]bool
+	relations  map[*model.Relation]bool
+	boundaries map[*model.Boundary]bool
+}
+
+func collectHighlights(m *model.Architecture, findings []types.Finding) highlights {
+	h := highlights{
+		containers: map[string]bool{},
+		relations:  map[*model.Relation]bool{},
+		boundaries: map[*model.Boundary]bool{},
+	}
+	active := make([]types.Finding, 0, len(findings))
+	for _, f := range findings {
+		if !f.Suppressed {
+			active = append(active, f)
+		}
+	}
+	findings = active
+	if len(findings) == 0 {
+		return h
+	}
+	for _, ref := range m.Containers() {
+		for _, f := range findings {
+			if pathWithin(f.Path, ref.Path) {
+				h.containers[ref.Container.Name] = true
+				break
+			}
+		}
+	}
+	for _, ref := range m.Relations() {
+		for _, f := range findings {
+			if pathWithin(f.Path, ref.Path) {
+				h.relations[ref.Relation] = true
+				break
+			}
+		}
+	}
+	walkBoundaries(m, func(b *model.Boundary, path string, _ int) {
+		for _, f := range findings {
+			if f.Path == path {
+				h.boundaries[b] = true
+				return
+			}
+		}
+	})
+	return h
+}
+
+func pathWithin(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+".")
+}
+
+// walkBoundaries visits boundaries depth-first with their index path and depth.
+func walkBoundaries(m *model.Architecture, fn func(b *model.Boundary, path string, depth int)) {
+	var visit func(b *model.Boundary, path string, depth int)
+	visit = func(b *model.Boundary, path string, depth int) {
+		fn(b, path, depth)
+		for i := range b.Boundaries {
+			visit(&b.Boundaries[i], fmt.Sprintf("%s.boundaries[%d]", path, i), depth+1)
+		}
+	}
+	for i := range m.Boundaries {
+		visit(&m.Boundaries[i], fmt.Sprintf("boundaries[%d]", i), 0)
+	}
+}
+
+// idSet hands out unique identifiers derived from element names.
+type idSet struct {
+	byName map[string]string
+	used   map[string]bool
+}
+
+func newIDSet() *idSet {
+	return &idSet{byName: map[string]string{}, used: map[string]bool{}}
+}
+
+func (s *idSet) id(prefix, name string) string {
+	key := prefix + "\x00" + name
+	if id, ok := s.byName[key]; ok {
+		return id
+	}
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	base := b.String()
+	id := base
+	for n := 2; s.used[id]; n++ {
+		id = fmt.Sprintf("%s_%d", base, n)
+	}
+	s.used[id] = true
+	s.byName[key] = id
+	return id
+}
+
+func relationLabel(rel *model.Relation) string {
+	label := string(rel.Kind)
+	if rel.Protocol != "" {
+		label += " (" + rel.Protocol + ")"
+	}
+	return label
+}
+
+type lineWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (lw *lineWriter) printf(depth int, format string, args ...any) {
+	if lw.err != nil {
+		return
+	}
+	_, lw.err = fmt.Fprintf(lw.w, strings.Repeat("  ", depth)+format+"\n", args...)
+}
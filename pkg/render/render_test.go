@@ -0,0 +1,69 @@
+package render_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/render"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+func TestWrite(t *testing.T) {
+	arch := &model.Architecture{
+		Version: 1,
+		Boundaries: []model.Boundary{{
+			Name: "Payments",
+			Containers: []model.Container{
+				{Name: "payments-api", Type: model.ContainerService},
+			},
+			Boundaries: []model.Boundary{{
+				Name:       "Ledger",
+				Containers: []model.Container{{Name: "ledger-db", Type: model.ContainerDatabase}},
+			}},
+			Relations: []model.Relation{
+				{From: "payments-api", To: "ledger-db", Kind: model.RelationKindDB},
+				{From: "payments-api", To: "antifraud", Kind: model.RelationKindAsync, Protocol: "kafka://fraud"},
+			},
+		}},
+		Externals: []model.Container{{Name: "antifraud", Type: model.ContainerExternal}},
+	}
+	opts := render.Options{Findings: []types.Finding{{RuleID: "ARCH-ACL", Path: "boundaries[0].relations[1]"}}}
+
+	cases := map[render.Format][]string{
+		render.FormatDOT: {
+			"subgraph cluster_Payments {",
+			"    subgraph cluster_Ledger {",
+			`"ledger-db" [label="ledger-db", shape=cylinder];`,
+			`"payments-api" -> "antifraud" [label="async (kafka://fraud)", style=dashed, color="red", fontcolor="red", penwidth=2];`,
+		},
+		render.FormatMermaid: {
+			`subgraph b_Payments ["Payments"]`,
+			`    subgraph b_Ledger ["Ledger"]`,
+			`c_payments_api -.->|"async (kafka://fraud)"| c_antifraud`,
+			"linkStyle 1 stroke:#d33",
+		},
+		render.FormatPlantUML: {
+			`rectangle "Payments" as b_Payments {`,
+			`  rectangle "Ledger" as b_Ledger {`,
+			`cloud "antifraud" as c_antifraud`,
+			"c_payments_api -[#red,bold,dashed]-> c_antifraud : async (kafka://fraud)",
+		},
+	}
+	for format, want := range cases {
+		var buf bytes.Buffer
+		if err := render.Write(&buf, format, arch, opts); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		for _, line := range want {
+			if !strings.Contains(buf.String(), line) {
+				t.Fatalf("%s output missing %q:\n%s", format, line, buf.String())
+			}
+		}
+	}
+
+	if err := render.Write(&bytes.Buffer{}, "svg", arch, opts); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
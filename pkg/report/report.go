@@ -8,13 +8,24 @@ import (
 	"github.com/PET-dev-projects/ArchLint/pkg/types"
 )
 
-// WriteText renders findings as a plain-text list.
+// WriteText renders findings as a plain-text list. Suppressed findings are
+// only counted.
 func WriteText(w io.Writer, findings []types.Finding) error {
-	if len(findings) == 0 {
-		_, err := fmt.Fprintln(w, "No findings")
-		return err
+	suppressed := 0
+	for _, f := range findings {
+		if f.Suppressed {
+			suppressed++
+		}
+	}
+	if len(findings) == suppressed {
+		if _, err := fmt.Fprintln(w, "No findings"); err != nil {
+			return err
+		}
 	}
 	for _, f := range findings {
+		if f.Suppressed {
+			continue
+		}
 		var meta string
 		if len(f.Meta) > 0 {
 			data, err := json.Marshal(f.Meta)
@@ -22,7 +33,11 @@ func WriteText(w io.Writer, findings []types.Finding) error {
 				meta = string(data)
 			}
 		}
-		line := fmt.Sprintf("%s\t%s\t%s\t%s", f.RuleID, f.Severity, f.Path, f.Message)
+		path := f.Path
+		if f.Location != nil {
+			path = fmt.Sprintf("%s (%s)", f.Path, f.Location)
+		}
+		line := fmt.Sprintf("%s\t%s\t%s\t%s", f.RuleID, f.Severity, path, f.Message)
 		if meta != "" {
 			line += "\t" + meta
 		}
@@ -30,6 +45,11 @@ func WriteText(w io.Writer, findings []types.Finding) error {
 			return err
 		}
 	}
+	if suppressed > 0 {
+		if _, err := fmt.Fprintf(w, "%d suppressed\n", suppressed); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
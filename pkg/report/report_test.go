@@ -0,0 +1,183 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/report"
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	findings := []types.Finding{{
+		RuleID:   "ARCH-ACL",
+		Severity: types.SeverityError,
+		Message:  "container api must declare one of [acl] to talk to external audit",
+		Path:     "boundaries[0].relations[2]",
+		Location: &types.Location{File: "arch.yaml", Line: 19, Column: 9},
+	}, {
+		RuleID:   "MODEL-0001",
+		Severity: types.SeverityWarn,
+		Message:  "synthetic",
+		Path:     "version",
+	}}
+	rules := []types.RuleMetadata{{ID: "ARCH-ACL", Description: "acl", DefaultSeverity: types.SeverityError}}
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, findings, rules); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation *struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log header: %s", buf.String())
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[1].ID != "MODEL-0001" {
+		t.Fatalf("expected unknown rule to be appended, got %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 2 || run.Results[1].RuleIndex != 1 || run.Results[1].Level != "warning" {
+		t.Fatalf("unexpected results: %+v", run.Results)
+	}
+	phys := run.Results[0].Locations[0].PhysicalLocation
+	if phys == nil || phys.ArtifactLocation.URI != "arch.yaml" || phys.Region.StartLine != 19 {
+		t.Fatalf("unexpected physical location: %+v", phys)
+	}
+}
+
+func TestWriteSARIFArtifactURIs(t *testing.T) {
+	abs, err := filepath.Abs(filepath.Join("models", "my arch.yaml"))
+	if err != nil {
+		t.Fatalf("abs: %v", err)
+	}
+	findings := []types.Finding{
+		{RuleID: "ARCH-ACL", Severity: types.SeverityError, Message: "a", Location: &types.Location{File: abs, Line: 1}},
+		{RuleID: "ARCH-ACL", Severity: types.SeverityError, Message: "b", Location: &types.Location{File: "teams/my arch.yaml", Line: 2}},
+	}
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, findings, nil); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI       string `json:"uri"`
+							URIBaseID string `json:"uriBaseId"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	results := log.Runs[0].Results
+
+	absolute := results[0].Locations[0].PhysicalLocation.ArtifactLocation
+	u, err := url.Parse(absolute.URI)
+	if err != nil || u.Scheme != "file" || absolute.URIBaseID != "" || !strings.HasSuffix(absolute.URI, "/models/my%20arch.yaml") {
+		t.Fatalf("expected file URI for absolute path, got %+v", absolute)
+	}
+	relative := results[1].Locations[0].PhysicalLocation.ArtifactLocation
+	if relative.URI != "teams/my%20arch.yaml" || relative.URIBaseID != "%SRCROOT%" {
+		t.Fatalf("expected URI relative to %%SRCROOT%%, got %+v", relative)
+	}
+}
+
+func TestWriteTextSuppressed(t *testing.T) {
+	findings := []types.Finding{{
+		RuleID:     "ARCH-ACL",
+		Severity:   types.SeverityError,
+		Message:    "container api must declare one of [acl] to talk to external audit",
+		Path:       "boundaries[0].relations[2]",
+		Suppressed: true,
+		Meta:       map[string]any{"suppressionReason": "legacy"},
+	}}
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text, findings); err != nil {
+		t.Fatalf("write text: %v", err)
+	}
+	if text.String() != "No findings\n1 suppressed\n" {
+		t.Fatalf("unexpected text output: %q", text.String())
+	}
+
+	var sarif bytes.Buffer
+	if err := report.WriteSARIF(&sarif, findings, nil); err != nil {
+		t.Fatalf("write sarif: %v", err)
+	}
+	if !bytes.Contains(sarif.Bytes(), []byte(`"kind": "inSource"`)) || !bytes.Contains(sarif.Bytes(), []byte(`"justification": "legacy"`)) {
+		t.Fatalf("expected in-source suppression, got %s", sarif.String())
+	}
+}
+
+func TestWriteRuleDetails(t *testing.T) {
+	rule := types.RuleMetadata{
+		ID:              "ARCH-CRUD",
+		Description:     "Databases are accessed only via CRUD-tagged containers.",
+		DefaultSeverity: types.SeverityError,
+		Rationale:       "Keeps schemas private.",
+		DocURL:          types.RuleDocURL("ARCH-CRUD"),
+		Classes:         []string{"db-kind", "exclusive"},
+		ClassSeverities: map[string]types.Severity{"exclusive": types.SeverityWarn},
+		Config: []types.ConfigField{{
+			Name:        "allowedTags",
+			Type:        "list of string",
+			Default:     []string{"crud"},
+			Description: "Tags that allow database access.",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteRuleDetails(&buf, rule); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"ARCH-CRUD (default severity: error)",
+		"Why: Keeps schemas private.",
+		"Message classes: db-kind, exclusive (warn)",
+		`allowedTags  list of string  default ["crud"]  Tags that allow database access.`,
+		"Docs: " + types.RulesDocURL + "#arch-crud",
+	} {
+		if !bytes.Contains([]byte(out), []byte(want)) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+// WriteRuleList renders one line per rule: ID, default severity and description.
+func WriteRuleList(w io.Writer, rules []types.RuleMetadata) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSEVERITY\tDESCRIPTION")
+	for _, rule := range rules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", rule.ID, rule.DefaultSeverity, rule.Description)
+	}
+	return tw.Flush()
+}
+
+// WriteRuleDetails renders everything known about a single rule.
+func WriteRuleDetails(w io.Writer, rule types.RuleMetadata) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (default severity: %s)\n", rule.ID, rule.DefaultSeverity)
+	if rule.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", rule.Description)
+	}
+	if rule.Rationale != "" {
+		fmt.Fprintf(&b, "\nWhy: %s\n", rule.Rationale)
+	}
+	if len(rule.Classes) > 0 {
+		classes := make([]string, len(rule.Classes))
+		for i, class := range rule.Classes {
+			classes[i] = class
+			if severity, ok := rule.ClassSeverities[class]; ok {
+				classes[i] += fmt.Sprintf(" (%s)", severity)
+			}
+		}
+		fmt.Fprintf(&b, "\nMessage classes: %s\n", strings.Join(classes, ", "))
+	}
+	if len(rule.Config) > 0 {
+		b.WriteString("\nConfiguration:\n")
+		tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+		for _, field := range rule.Config {
+			def := ""
+			if field.Default != nil {
+				data, err := json.Marshal(field.Default)
+				if err != nil {
+					return err
+				}
+				def = "default " + string(data)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", field.Name, field.Type, def, field.Description)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	if rule.DocURL != "" {
+		fmt.Fprintf(&b, "\nDocs: %s\n", rule.DocURL)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteRulesJSON serializes rule metadata as a JSON array.
+func WriteRulesJSON(w io.Writer, rules []types.RuleMetadata) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rules)
+}
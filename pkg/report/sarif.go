@@ -0,0 +1,208 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/types"
+)
+
+const (
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion  = "2.1.0"
+	sarifToolName = "ArchLint"
+	sarifToolURI  = "https://github.com/PET-dev-projects/ArchLint"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     *sarifMessage      `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   map[string]any     `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// WriteSARIF serializes findings as a SARIF 2.1.0 log. Rules describes the
+// tool.driver.rules section; rule IDs found only in findings are appended
+// with their observed severity.
+func WriteSARIF(w io.Writer, findings []types.Finding, rules []types.RuleMetadata) error {
+	driver := sarifDriver{
+		Name:           sarifToolName,
+		InformationURI: sarifToolURI,
+		Rules:          make([]sarifRule, 0, len(rules)),
+	}
+	ruleIndex := map[string]int{}
+	addRule := func(meta types.RuleMetadata) {
+		if _, ok := ruleIndex[meta.ID]; ok {
+			return
+		}
+		rule := sarifRule{
+			ID:                   meta.ID,
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(meta.DefaultSeverity)},
+		}
+		if meta.Description != "" {
+			rule.ShortDescription = &sarifMessage{Text: meta.Description}
+		}
+		if meta.Rationale != "" {
+			rule.FullDescription = &sarifMessage{Text: meta.Rationale}
+		}
+		rule.HelpURI = meta.DocURL
+		ruleIndex[meta.ID] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, rule)
+	}
+	for _, meta := range rules {
+		addRule(meta)
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		addRule(types.RuleMetadata{ID: f.RuleID, DefaultSeverity: f.Severity})
+		results = append(results, sarifResult{
+			RuleID:       f.RuleID,
+			RuleIndex:    ruleIndex[f.RuleID],
+			Level:        sarifLevel(f.Severity),
+			Message:      sarifMessage{Text: f.Message},
+			Locations:    sarifLocations(f),
+			Suppressions: sarifSuppressions(f),
+			Properties:   f.Meta,
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: driver},
+			Results: results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func sarifLocations(f types.Finding) []sarifLocation {
+	loc := sarifLocation{}
+	if f.Path != "" {
+		loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Path}}
+	}
+	if f.Location != nil && f.Location.File != "" {
+		loc.PhysicalLocation = &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifact(f.Location.File),
+			Region: sarifRegion{
+				StartLine:   f.Location.Line,
+				StartColumn: f.Location.Column,
+			},
+		}
+	}
+	if loc.PhysicalLocation == nil && loc.LogicalLocations == nil {
+		return nil
+	}
+	return []sarifLocation{loc}
+}
+
+// sarifArtifact turns a file path into the URI SARIF expects: a file:// URI
+// for absolute paths, or a reference relative to %SRCROOT% otherwise.
+func sarifArtifact(file string) sarifArtifactLocation {
+	path := filepath.ToSlash(file)
+	if !filepath.IsAbs(file) {
+		return sarifArtifactLocation{URI: (&url.URL{Path: path}).String(), URIBaseID: "%SRCROOT%"}
+	}
+	if !strings.HasPrefix(path, "/") {
+		// Windows drive paths such as C:/repo/arch.yaml.
+		path = "/" + path
+	}
+	return sarifArtifactLocation{URI: (&url.URL{Scheme: "file", Path: path}).String()}
+}
+
+func sarifSuppressions(f types.Finding) []sarifSuppression {
+	if !f.Suppressed {
+		return nil
+	}
+	reason, _ := f.Meta["suppressionReason"].(string)
+	return []sarifSuppression{{Kind: "inSource", Justification: reason}}
+}
+
+func sarifLevel(s types.Severity) string {
+	switch s {
+	case types.SeverityError:
+		return "error"
+	case types.SeverityWarn:
+		return "warning"
+	case types.SeverityInfo:
+		return "note"
+	default:
+		return "none"
+	}
+}
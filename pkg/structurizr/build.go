@@ -0,0 +1,213 @@
+package structurizr
+
+import (
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// build converts the parsed element tree into an architecture model.
+func (p *parser) build() *model.Architecture {
+	arch := &model.Architecture{Version: 1}
+	if len(p.meta) > 0 {
+		arch.Meta = p.meta
+	}
+
+	// homes maps container elements to the index path of their boundary;
+	// externals have no entry.
+	homes := map[*dslElement][]int{}
+	for _, el := range p.top {
+		switch {
+		case el.kind == kindSystem && !hasContainers(el):
+			arch.Externals = append(arch.Externals, p.external(el))
+		case hasContainers(el):
+			arch.Boundaries = append(arch.Boundaries, p.boundary(arch, el, []int{len(arch.Boundaries)}, homes))
+		default:
+			p.warn(el.line, "%s %q has no containers; skipped", el.kind, el.name)
+		}
+	}
+
+	for _, rel := range p.relations {
+		from := rel.source
+		if from == nil {
+			from = p.ids[rel.from]
+		}
+		to := p.ids[rel.to]
+		if from == nil || to == nil {
+			p.warn(rel.line, "relationship %s -> %s references an unknown identifier; skipped", rel.from, rel.to)
+			continue
+		}
+		if !mappable(from, homes) || !mappable(to, homes) {
+			p.warn(rel.line, "relationship %s -> %s connects elements that are not containers; skipped", from.name, to.name)
+			continue
+		}
+		home, ok := homes[from]
+		if !ok {
+			home, ok = homes[to]
+		}
+		if !ok {
+			p.warn(rel.line, "relationship %s -> %s connects two externals; skipped", from.name, to.name)
+			continue
+		}
+
+		relation := model.Relation{
+			From:        from.name,
+			To:          to.name,
+			Kind:        relationKind(rel.tags, to),
+			Description: rel.description,
+			Protocol:    rel.technology,
+			Tags:        userTags(rel.tags, "sync", "async", "db"),
+		}
+		if len(rel.props) > 0 {
+			relation.Meta = rel.props
+		}
+		b := &arch.Boundaries[home[0]]
+		for _, idx := range home[1:] {
+			b = &b.Boundaries[idx]
+		}
+		b.Relations = append(b.Relations, relation)
+	}
+	return arch
+}
+
+func (p *parser) boundary(arch *model.Architecture, el *dslElement, path []int, homes map[*dslElement][]int) model.Boundary {
+	b := model.Boundary{
+		Name:        el.name,
+		Description: el.description,
+		Tags:        userTags(el.tags),
+	}
+	b.Owner, b.Meta = splitOwner(el.props)
+	for _, child := range el.children {
+		switch {
+		case child.kind == kindContainer:
+			b.Containers = append(b.Containers, p.container(child))
+			homes[child] = path
+		case hasContainers(child):
+			nested := append(append([]int{}, path...), len(b.Boundaries))
+			b.Boundaries = append(b.Boundaries, p.boundary(arch, child, nested, homes))
+		case child.kind == kindSystem:
+			arch.Externals = append(arch.Externals, p.external(child))
+		default:
+			p.warn(child.line, "%s %q has no containers; skipped", child.kind, child.name)
+		}
+	}
+	if b.Containers == nil {
+		b.Containers = []model.Container{}
+	}
+	return b
+}
+
+func (p *parser) container(el *dslElement) model.Container {
+	c := model.Container{
+		Name:        el.name,
+		Type:        model.ContainerService,
+		Description: el.description,
+		Technology:  el.technology,
+		Tags:        userTags(el.tags, tagDatabase, tagExternal),
+	}
+	switch {
+	case hasTag(el.tags, tagDatabase):
+		c.Type = model.ContainerDatabase
+	case hasTag(el.tags, tagExternal):
+		c.Type = model.ContainerExternal
+	}
+	props := model.Metadata{}
+	for k, v := range el.props {
+		props[k] = v
+	}
+	if protocol, ok := props[propertyProtocol]; ok {
+		c.Protocol = protocol
+		delete(props, propertyProtocol)
+	}
+	c.Owner, c.Meta = splitOwner(props)
+	return c
+}
+
+func (p *parser) external(el *dslElement) model.Container {
+	if !hasTag(el.tags, tagExternal) {
+		p.warn(el.line, "software system %q has no containers; imported as external", el.name)
+	}
+	c := p.container(el)
+	c.Type = model.ContainerExternal
+	c.Technology = ""
+	return c
+}
+
+func splitOwner(props model.Metadata) (string, model.Metadata) {
+	owner := ""
+	meta := model.Metadata{}
+	for k, v := range props {
+		if k == propertyOwner {
+			owner = v
+			continue
+		}
+		meta[k] = v
+	}
+	if len(meta) == 0 {
+		return owner, nil
+	}
+	return owner, meta
+}
+
+func hasContainers(el *dslElement) bool {
+	for _, child := range el.children {
+		if child.kind == kindContainer || hasContainers(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func mappable(el *dslElement, homes map[*dslElement][]int) bool {
+	if el.kind == kindContainer {
+		_, ok := homes[el]
+		return ok
+	}
+	return el.kind == kindSystem && !hasContainers(el)
+}
+
+func relationKind(tags []string, to *dslElement) model.RelationKind {
+	for _, tag := range tags {
+		switch kind := model.RelationKind(strings.ToLower(tag)); kind {
+		case model.RelationKindSync, model.RelationKindAsync, model.RelationKindDB:
+			return kind
+		}
+	}
+	if to.kind == kindContainer && hasTag(to.tags, tagDatabase) {
+		return model.RelationKindDB
+	}
+	return model.RelationKindSync
+}
+
+// userTags drops Structurizr built-in tags and the given reserved ones.
+func userTags(tags []string, reserved ...string) []string {
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if _, ok := builtinTags[tag]; ok {
+			continue
+		}
+		skip := false
+		for _, r := range reserved {
+			if strings.EqualFold(tag, r) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			out = append(out, tag)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func hasTag(tags []string, want string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag, want) {
+			return true
+		}
+	}
+	return false
+}
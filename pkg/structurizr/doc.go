@@ -0,0 +1,7 @@
+// Package structurizr converts between architecture models and the
+// Structurizr DSL used for C4 modelling. Software systems with containers map
+// to boundaries, groups to nested boundaries, container-less software systems
+// to externals and relationships to relations (technology <-> protocol).
+// Constructs without a counterpart are reported as warnings instead of being
+// dropped silently.
+package structurizr
@@ -0,0 +1,254 @@
+package structurizr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+// Export writes m as a Structurizr DSL workspace.
+func Export(w io.Writer, m *model.Architecture) ([]Warning, error) {
+	e := &exporter{
+		ids:     map[string]string{},
+		used:    map[string]bool{},
+		builder: &strings.Builder{},
+	}
+	for _, ref := range m.Containers() {
+		e.identifier(containerKey, ref.Container.Name)
+	}
+	e.write(m)
+	if _, err := io.WriteString(w, e.builder.String()); err != nil {
+		return nil, err
+	}
+	return e.warnings, nil
+}
+
+// Identifier namespaces so a boundary and a container may share a name.
+const (
+	containerKey = "c:"
+	boundaryKey  = "b:"
+)
+
+type exporter struct {
+	ids      map[string]string
+	used     map[string]bool
+	builder  *strings.Builder
+	warnings []Warning
+}
+
+func (e *exporter) line(depth int, format string, args ...any) {
+	e.builder.WriteString(strings.Repeat("    ", depth))
+	fmt.Fprintf(e.builder, format, args...)
+	e.builder.WriteByte('\n')
+}
+
+func (e *exporter) warn(format string, args ...any) {
+	e.warnings = append(e.warnings, Warning{Message: fmt.Sprintf(format, args...)})
+}
+
+func (e *exporter) identifier(kind, name string) string {
+	key := kind + name
+	if id, ok := e.ids[key]; ok {
+		return id
+	}
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	base := b.String()
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "e_" + base
+	}
+	id := base
+	for n := 2; e.used[id]; n++ {
+		id = fmt.Sprintf("%s_%d", base, n)
+	}
+	e.used[id] = true
+	e.ids[key] = id
+	return id
+}
+
+func (e *exporter) write(m *model.Architecture) {
+	e.line(0, "workspace {")
+	e.properties(1, m.Meta, "")
+	e.line(1, "model {")
+	if nestedGroups(m) {
+		e.line(2, "properties {")
+		e.line(3, "%s %s", quote("structurizr.groupSeparator"), quote("/"))
+		e.line(2, "}")
+	}
+	for i := range m.Boundaries {
+		e.system(2, &m.Boundaries[i])
+	}
+	for i := range m.Externals {
+		c := &m.Externals[i]
+		if c.Type != model.ContainerExternal {
+			e.warn("top-level external %s has type %q; exported as an external software system", c.Name, c.Type)
+		}
+		if c.Technology != "" {
+			e.warn("technology of external %s is dropped; software systems have no technology", c.Name)
+		}
+		e.interfaces(c)
+		e.suppression("external "+c.Name, c.Archlint)
+		tags := append([]string{tagExternal}, c.Tags...)
+		e.element(2, e.identifier(containerKey, c.Name), "softwareSystem", []string{c.Name, c.Description, strings.Join(tags, ",")}, c.Owner, c.Meta, c.Protocol)
+	}
+	for _, ref := range m.Relations() {
+		rel := ref.Relation
+		from, okFrom := e.ids[containerKey+rel.From]
+		to, okTo := e.ids[containerKey+rel.To]
+		if !okFrom || !okTo {
+			e.warn("relation %s -> %s references an unknown container; skipped", rel.From, rel.To)
+			continue
+		}
+		e.suppression(fmt.Sprintf("relation %s -> %s", rel.From, rel.To), rel.Archlint)
+		if rel.Uses != "" {
+			e.warn("relation %s -> %s uses interface %s; the reference is dropped", rel.From, rel.To, rel.Uses)
+		}
+		tags := append([]string{}, rel.Tags...)
+		if rel.Kind != "" {
+			tags = append([]string{string(rel.Kind)}, tags...)
+		}
+		args := trimArgs([]string{rel.Description, rel.Protocol, strings.Join(tags, ",")})
+		if len(rel.Meta) == 0 {
+			e.line(2, "%s -> %s %s", from, to, joinQuoted(args))
+			continue
+		}
+		e.line(2, "%s -> %s %s {", from, to, joinQuoted(args))
+		e.properties(3, rel.Meta, "")
+		e.line(2, "}")
+	}
+	e.line(1, "}")
+	e.line(0, "}")
+}
+
+func (e *exporter) system(depth int, b *model.Boundary) {
+	e.line(depth, "%s = softwareSystem %s {", e.identifier(boundaryKey, b.Name), joinQuoted(trimArgs([]string{b.Name, b.Description, strings.Join(b.Tags, ",")})))
+	e.properties(depth+1, b.Meta, b.Owner)
+	e.suppression("boundary "+b.Name, b.Archlint)
+	e.boundaryBody(depth+1, b)
+	e.line(depth, "}")
+}
+
+func (e *exporter) group(depth int, b *model.Boundary) {
+	e.line(depth, "group %s {", quote(b.Name))
+	if b.Description != "" || len(b.Tags) > 0 || b.Owner != "" || len(b.Meta) > 0 {
+		e.warn("nested boundary %s is exported as a group; its description, tags, owner and meta are dropped", b.Name)
+	}
+	e.suppression("boundary "+b.Name, b.Archlint)
+	e.boundaryBody(depth+1, b)
+	e.line(depth, "}")
+}
+
+func (e *exporter) boundaryBody(depth int, b *model.Boundary) {
+	for i := range b.Containers {
+		c := &b.Containers[i]
+		tags := append([]string{}, c.Tags...)
+		switch c.Type {
+		case model.ContainerDatabase:
+			tags = append([]string{tagDatabase}, tags...)
+		case model.ContainerExternal:
+			e.warn("external %s declared inside boundary %s is exported as a container tagged %s", c.Name, b.Name, tagExternal)
+			tags = append([]string{tagExternal}, tags...)
+		}
+		e.interfaces(c)
+		e.suppression("container "+c.Name, c.Archlint)
+		e.element(depth, e.identifier(containerKey, c.Name), "container", []string{c.Name, c.Description, c.Technology, strings.Join(tags, ",")}, c.Owner, c.Meta, c.Protocol)
+	}
+	for i := range b.Boundaries {
+		e.group(depth, &b.Boundaries[i])
+	}
+}
+
+// interfaces warns about the provided interfaces of c, which have no
+// Structurizr counterpart.
+func (e *exporter) interfaces(c *model.Container) {
+	for _, iface := range c.Provides {
+		e.warn("%s interface %s of %s is dropped; Structurizr elements do not declare interfaces", iface.Kind, iface.Name, c.Name)
+	}
+}
+
+// suppression warns about an inline archlint suppression on subject, which
+// has no Structurizr counterpart.
+func (e *exporter) suppression(subject string, s *model.Suppression) {
+	if s != nil {
+		e.warn("archlint suppression of %s on %s is dropped", strings.Join(s.Ignore, ", "), subject)
+	}
+}
+
+func (e *exporter) element(depth int, id, keyword string, args []string, owner string, meta model.Metadata, protocol string) {
+	args = trimArgs(args)
+	if owner == "" && protocol == "" && len(meta) == 0 {
+		e.line(depth, "%s = %s %s", id, keyword, joinQuoted(args))
+		return
+	}
+	e.line(depth, "%s = %s %s {", id, keyword, joinQuoted(args))
+	props := model.Metadata{}
+	for k, v := range meta {
+		props[k] = v
+	}
+	if protocol != "" {
+		props[propertyProtocol] = protocol
+	}
+	e.properties(depth+1, props, owner)
+	e.line(depth, "}")
+}
+
+func (e *exporter) properties(depth int, meta model.Metadata, owner string) {
+	if len(meta) == 0 && owner == "" {
+		return
+	}
+	e.line(depth, "properties {")
+	if owner != "" {
+		e.line(depth+1, "%s %s", quote(propertyOwner), quote(owner))
+	}
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		e.line(depth+1, "%s %s", quote(k), quote(meta[k]))
+	}
+	e.line(depth, "}")
+}
+
+func nestedGroups(m *model.Architecture) bool {
+	for _, b := range m.Boundaries {
+		for _, nested := range b.Boundaries {
+			if len(nested.Boundaries) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// trimArgs drops trailing empty positional arguments.
+func trimArgs(args []string) []string {
+	end := len(args)
+	for end > 1 && args[end-1] == "" {
+		end--
+	}
+	return args[:end]
+}
+
+func joinQuoted(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,350 @@
+package structurizr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+)
+
+const (
+	kindSystem    = "softwaresystem"
+	kindContainer = "container"
+	kindGroup     = "group"
+)
+
+// unsupportedElements are C4 elements without a model counterpart.
+var unsupportedElements = map[string]string{
+	"person":                 "person",
+	"component":              "component",
+	"element":                "custom element",
+	"deploymentenvironment":  "deployment environment",
+	"deploymentnode":         "deployment node",
+	"infrastructurenode":     "infrastructure node",
+	"softwaresysteminstance": "software system instance",
+	"containerinstance":      "container instance",
+}
+
+// ignoredBlocks carry presentation or documentation only.
+var ignoredBlocks = map[string]struct{}{
+	"views":         {},
+	"styles":        {},
+	"configuration": {},
+	"branding":      {},
+	"terminology":   {},
+	"perspectives":  {},
+	"users":         {},
+}
+
+type dslElement struct {
+	kind        string
+	id          string
+	name        string
+	description string
+	technology  string
+	tags        []string
+	props       model.Metadata
+	parent      *dslElement
+	children    []*dslElement
+	line        int
+}
+
+type dslRelation struct {
+	source      *dslElement
+	from, to    string
+	description string
+	technology  string
+	tags        []string
+	props       model.Metadata
+	line        int
+}
+
+// Import parses a Structurizr DSL workspace into an architecture model.
+func Import(r io.Reader) (*model.Architecture, []Warning, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	stmts, err := lex(string(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &parser{stmts: stmts, ids: map[string]*dslElement{}, meta: model.Metadata{}}
+	if err := p.parse(); err != nil {
+		return nil, nil, err
+	}
+	arch := p.build()
+	return arch, p.warnings, nil
+}
+
+type parser struct {
+	stmts     []statement
+	pos       int
+	ids       map[string]*dslElement
+	top       []*dslElement
+	relations []*dslRelation
+	meta      model.Metadata
+	warnings  []Warning
+}
+
+func (p *parser) warn(line int, format string, args ...any) {
+	p.warnings = append(p.warnings, Warning{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) parse() error {
+	if len(p.stmts) == 0 {
+		return fmt.Errorf("empty workspace")
+	}
+	first := p.stmts[0]
+	if len(first.tokens) == 0 || !strings.EqualFold(first.tokens[0].text, "workspace") || !first.opens {
+		return fmt.Errorf("line %d: expected workspace block", first.line)
+	}
+	if len(first.tokens) > 1 && first.tokens[1].is("extends") {
+		p.warn(first.line, "workspace extends is not supported; only this file is imported")
+	}
+	p.pos = 1
+	p.body(func(s statement) {
+		keyword := strings.ToLower(s.tokens[0].text)
+		switch {
+		case keyword == "model" && s.opens:
+			p.body(func(s statement) { p.modelStatement(s, nil) })
+		case keyword == "properties" && s.opens:
+			p.properties(p.meta)
+		case keyword == "name" || keyword == "description" || strings.HasPrefix(keyword, "!"):
+			p.skip(s)
+		default:
+			if _, ok := ignoredBlocks[keyword]; !ok {
+				p.warn(s.line, "unsupported workspace statement %q skipped", s.tokens[0].text)
+			}
+			p.skip(s)
+		}
+	})
+	return nil
+}
+
+// body feeds statements to fn until the block closes.
+func (p *parser) body(fn func(statement)) {
+	for p.pos < len(p.stmts) {
+		s := p.stmts[p.pos]
+		p.pos++
+		if s.closes {
+			return
+		}
+		if len(s.tokens) == 0 {
+			if s.opens {
+				p.skipBlock()
+			}
+			continue
+		}
+		fn(s)
+	}
+}
+
+func (p *parser) skip(s statement) {
+	if s.opens {
+		p.skipBlock()
+	}
+}
+
+func (p *parser) skipBlock() {
+	depth := 1
+	for p.pos < len(p.stmts) && depth > 0 {
+		s := p.stmts[p.pos]
+		p.pos++
+		if s.opens {
+			depth++
+		}
+		if s.closes {
+			depth--
+		}
+	}
+}
+
+func (p *parser) properties(dst model.Metadata) {
+	p.body(func(s statement) {
+		if len(s.tokens) < 2 {
+			p.warn(s.line, "property without value skipped")
+			p.skip(s)
+			return
+		}
+		dst[s.tokens[0].text] = s.tokens[1].text
+		p.skip(s)
+	})
+}
+
+// modelStatement handles a statement inside model, group or element blocks.
+func (p *parser) modelStatement(s statement, parent *dslElement) {
+	tokens := s.tokens
+	id := ""
+	if len(tokens) >= 3 && tokens[1].is("=") {
+		id = tokens[0].text
+		tokens = tokens[2:]
+	}
+
+	if arrow := indexArrow(tokens); arrow >= 0 {
+		p.relationship(s, tokens, arrow, parent)
+		return
+	}
+
+	keyword := strings.ToLower(tokens[0].text)
+	args := texts(tokens[1:])
+	switch keyword {
+	case kindSystem:
+		if parent != nil && parent.kind != kindGroup {
+			p.warn(s.line, "software system %q nested in %s skipped", arg(args, 0), parent.kind)
+			p.skip(s)
+			return
+		}
+		p.element(s, parent, &dslElement{kind: kindSystem, id: id, name: arg(args, 0), description: arg(args, 1), tags: splitTags(arg(args, 2))})
+	case kindContainer:
+		if !insideSystem(parent) {
+			p.warn(s.line, "container %q outside a software system skipped", arg(args, 0))
+			p.skip(s)
+			return
+		}
+		p.element(s, parent, &dslElement{kind: kindContainer, id: id, name: arg(args, 0), description: arg(args, 1), technology: arg(args, 2), tags: splitTags(arg(args, 3))})
+	case kindGroup:
+		p.element(s, parent, &dslElement{kind: kindGroup, id: id, name: arg(args, 0)})
+	case "enterprise":
+		p.warn(s.line, "enterprise boundary %q dropped; its elements are imported", arg(args, 0))
+		if s.opens {
+			p.body(func(s statement) { p.modelStatement(s, parent) })
+		}
+	case "description", "technology", "tags", "url", "properties":
+		if parent == nil || parent.kind == kindGroup {
+			if keyword != "properties" {
+				p.warn(s.line, "%s outside an element skipped", keyword)
+			}
+			if s.opens {
+				p.properties(model.Metadata{})
+			}
+			return
+		}
+		p.attribute(s, keyword, args, &parent.description, &parent.technology, &parent.tags, parent.props)
+	default:
+		if label, ok := unsupportedElements[keyword]; ok {
+			p.warn(s.line, "%s %q has no ArchLint equivalent; skipped", label, arg(args, 0))
+		} else if strings.HasPrefix(keyword, "!") {
+			if keyword == "!include" {
+				p.warn(s.line, "!include is not supported; %q skipped", arg(args, 0))
+			}
+		} else if _, ok := ignoredBlocks[keyword]; !ok {
+			p.warn(s.line, "unsupported statement %q skipped", tokens[0].text)
+		}
+		p.skip(s)
+	}
+}
+
+func (p *parser) attribute(s statement, keyword string, args []string, description, technology *string, tags *[]string, props model.Metadata) {
+	switch keyword {
+	case "description":
+		*description = arg(args, 0)
+	case "technology":
+		*technology = arg(args, 0)
+	case "tags":
+		for _, a := range args {
+			*tags = append(*tags, splitTags(a)...)
+		}
+	case "properties":
+		if s.opens {
+			p.properties(props)
+			return
+		}
+	}
+	p.skip(s)
+}
+
+func (p *parser) element(s statement, parent *dslElement, el *dslElement) {
+	el.parent = parent
+	el.line = s.line
+	el.props = model.Metadata{}
+	if parent == nil {
+		p.top = append(p.top, el)
+	} else {
+		parent.children = append(parent.children, el)
+	}
+	if el.id != "" {
+		p.ids[el.id] = el
+		if owner := identified(parent); owner != nil {
+			p.ids[owner.id+"."+el.id] = el
+		}
+	}
+	if s.opens {
+		p.body(func(s statement) { p.modelStatement(s, el) })
+	}
+}
+
+func (p *parser) relationship(s statement, tokens []token, arrow int, parent *dslElement) {
+	rel := &dslRelation{line: s.line, props: model.Metadata{}}
+	switch {
+	case arrow == 0 && parent != nil && parent.kind != kindGroup:
+		rel.source = parent
+	case arrow == 1:
+		rel.from = tokens[0].text
+	default:
+		p.warn(s.line, "relationship without a source skipped")
+		p.skip(s)
+		return
+	}
+	if arrow+1 >= len(tokens) {
+		p.warn(s.line, "relationship without a destination skipped")
+		p.skip(s)
+		return
+	}
+	rel.to = tokens[arrow+1].text
+	args := texts(tokens[arrow+2:])
+	rel.description = arg(args, 0)
+	rel.technology = arg(args, 1)
+	rel.tags = splitTags(arg(args, 2))
+	p.relations = append(p.relations, rel)
+	if s.opens {
+		p.body(func(s statement) {
+			keyword := strings.ToLower(s.tokens[0].text)
+			p.attribute(s, keyword, texts(s.tokens[1:]), &rel.description, &rel.technology, &rel.tags, rel.props)
+		})
+	}
+}
+
+func indexArrow(tokens []token) int {
+	for i, t := range tokens {
+		if t.is("->") {
+			return i
+		}
+	}
+	return -1
+}
+
+func insideSystem(el *dslElement) bool {
+	for ; el != nil; el = el.parent {
+		if el.kind == kindSystem {
+			return true
+		}
+	}
+	return false
+}
+
+// identified returns the closest ancestor with an identifier, skipping groups.
+func identified(el *dslElement) *dslElement {
+	for ; el != nil; el = el.parent {
+		if el.kind != kindGroup && el.id != "" {
+			return el
+		}
+	}
+	return nil
+}
+
+func texts(tokens []token) []string {
+	out := make([]string, len(tokens))
+	for i, t := range tokens {
+		out[i] = t.text
+	}
+	return out
+}
+
+func arg(args []string, idx int) string {
+	if idx < len(args) {
+		return args[idx]
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package structurizr
+
+import (
+	"fmt"
+	"strings"
+)
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// is reports whether t is the unquoted word w.
+func (t token) is(w string) bool {
+	return !t.quoted && t.text == w
+}
+
+// statement is one DSL line (or the part of it before an opening brace).
+type statement struct {
+	line   int
+	tokens []token
+	opens  bool
+	closes bool
+}
+
+// lex splits DSL source into statements. Opening braces terminate a
+// statement and closing braces become statements of their own, so one-line
+// blocks such as `properties { "k" "v" }` parse like multi-line ones.
+func lex(src string) ([]statement, error) {
+	stmts := make([]statement, 0)
+	current := statement{line: 1}
+	line := 1
+	flush := func() {
+		if len(current.tokens) > 0 || current.opens {
+			stmts = append(stmts, current)
+		}
+		current = statement{line: line}
+	}
+
+	runes := []rune(src)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			line++
+			flush()
+		case r == ' ' || r == '\t' || r == '\r':
+		case (r == '#' && len(current.tokens) == 0) || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			i--
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/') {
+				if runes[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			i++
+		case r == '{':
+			current.opens = true
+			flush()
+		case r == '}':
+			flush()
+			stmts = append(stmts, statement{line: line, closes: true})
+		case r == '"':
+			start := line
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated string", start)
+			}
+			current.tokens = append(current.tokens, token{text: b.String(), quoted: true})
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\r\n{}\"", runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			i--
+			current.tokens = append(current.tokens, splitArrow(word)...)
+		}
+	}
+	flush()
+	return stmts, nil
+}
+
+// splitArrow separates relationship arrows written without spaces (a->b).
+func splitArrow(word string) []token {
+	if word == "->" || !strings.Contains(word, "->") {
+		return []token{{text: word}}
+	}
+	tokens := make([]token, 0, 3)
+	for idx, part := range strings.Split(word, "->") {
+		if idx > 0 {
+			tokens = append(tokens, token{text: "->"})
+		}
+		if part != "" {
+			tokens = append(tokens, token{text: part})
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,47 @@
+package structurizr
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	tagDatabase = "Database"
+	tagExternal = "External"
+
+	propertyOwner    = "owner"
+	propertyProtocol = "protocol"
+)
+
+// builtinTags are added implicitly by Structurizr and carry no model meaning.
+var builtinTags = map[string]struct{}{
+	"Element":         {},
+	"Software System": {},
+	"Container":       {},
+	"Relationship":    {},
+	"Group":           {},
+}
+
+// Warning describes a construct that could not be converted faithfully.
+type Warning struct {
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (w Warning) String() string {
+	if w.Line > 0 {
+		return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+	}
+	return w.Message
+}
+
+func splitTags(value string) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
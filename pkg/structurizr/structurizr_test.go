@@ -0,0 +1,141 @@
+package structurizr_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PET-dev-projects/ArchLint/pkg/diff"
+	"github.com/PET-dev-projects/ArchLint/pkg/model"
+	"github.com/PET-dev-projects/ArchLint/pkg/structurizr"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	for _, name := range []string{"payments.yaml", "music_streaming.yaml"} {
+		arch, err := model.LoadModelFromFile(filepath.Join("..", "..", "examples", name))
+		if err != nil {
+			t.Fatalf("load %s: %v", name, err)
+		}
+		var buf bytes.Buffer
+		if _, err := structurizr.Export(&buf, arch); err != nil {
+			t.Fatalf("export %s: %v", name, err)
+		}
+		imported, warnings, err := structurizr.Import(&buf)
+		if err != nil {
+			t.Fatalf("import %s: %v\n%s", name, err, buf.String())
+		}
+		if len(warnings) != 0 {
+			t.Fatalf("unexpected warnings for %s: %v", name, warnings)
+		}
+		if res := diff.Compare(arch, imported); !res.Empty() {
+			t.Fatalf("round trip of %s changed the model: %+v", name, res.Changes)
+		}
+		if findings := model.ValidateModel(imported); len(findings) != 0 {
+			t.Fatalf("imported %s does not validate: %v", name, findings)
+		}
+	}
+}
+
+func TestImportWarnsOnUnmappableConstructs(t *testing.T) {
+	src := `
+workspace "Shop" {
+    model {
+        customer = person "Customer"
+        shop = softwareSystem "Shop" {
+            web = container "web" "Storefront" "Go" "edge"
+            db = container "orders-db" "" "Postgres" "Database"
+            web -> db "reads orders"
+            web -> api "calls" "https"
+            api = container "api" {
+                technology "Go"
+                tags "acl"
+                properties {
+                    "owner" "team-shop"
+                }
+                worker = component "worker"
+            }
+        }
+        psp = softwareSystem "PSP" "Payments" "External"
+        customer -> web "browses"
+        api -> psp "charges" "https://gateway.psp" "async"
+    }
+    views {
+        systemLandscape {
+            include *
+        }
+    }
+}
+`
+	arch, warnings, err := structurizr.Import(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(warnings) != 3 {
+		t.Fatalf("expected person, component and person relationship warnings, got %v", warnings)
+	}
+	if len(arch.Boundaries) != 1 || len(arch.Boundaries[0].Containers) != 3 || len(arch.Externals) != 1 {
+		t.Fatalf("unexpected model: %+v", arch)
+	}
+	db := arch.Boundaries[0].Containers[1]
+	if db.Type != model.ContainerDatabase || db.Technology != "Postgres" {
+		t.Fatalf("expected database container, got %+v", db)
+	}
+	api := arch.Boundaries[0].Containers[2]
+	if api.Owner != "team-shop" || api.Technology != "Go" || len(api.Tags) != 1 {
+		t.Fatalf("expected api attributes from body, got %+v", api)
+	}
+	rels := arch.Boundaries[0].Relations
+	if len(rels) != 3 || rels[0].Kind != model.RelationKindDB || rels[2].Kind != model.RelationKindAsync || rels[2].Protocol != "https://gateway.psp" {
+		t.Fatalf("unexpected relations: %+v", rels)
+	}
+	if findings := model.ValidateModel(arch); len(findings) != 0 {
+		t.Fatalf("imported model does not validate: %v", findings)
+	}
+}
+
+func TestExportWarnsOnDroppedInterfaces(t *testing.T) {
+	arch, err := model.LoadModelFromFile(filepath.Join("..", "..", "testdata", "arch_interfaces.yaml"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	var buf bytes.Buffer
+	warnings, err := structurizr.Export(&buf, arch)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var provides, uses int
+	for _, w := range warnings {
+		switch {
+		case strings.Contains(w.Message, "interface") && strings.Contains(w.Message, "do not declare interfaces"):
+			provides++
+		case strings.Contains(w.Message, " uses interface "):
+			uses++
+		}
+	}
+	if provides != 6 || uses != 3 || len(warnings) != 9 {
+		t.Fatalf("expected a warning per interface and per uses reference, got %v", warnings)
+	}
+}
+
+func TestExportWarnsOnDroppedSuppressions(t *testing.T) {
+	arch, err := model.LoadModelFromFile(filepath.Join("..", "..", "testdata", "arch_suppressions.yaml"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	arch.Boundaries[0].Containers[1].Archlint = &model.Suppression{Ignore: []string{"ARCH-ACL"}, Reason: "Legacy."}
+	var buf bytes.Buffer
+	warnings, err := structurizr.Export(&buf, arch)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	want := []string{"boundary Core Services", "container legacy", "relation api -> audit", "relation legacy -> audit", "relation api -> db"}
+	if len(warnings) != len(want) {
+		t.Fatalf("expected a warning per suppression, got %v", warnings)
+	}
+	for i, subject := range want {
+		if !strings.Contains(warnings[i].Message, "suppression") || !strings.HasSuffix(warnings[i].Message, "on "+subject+" is dropped") {
+			t.Fatalf("warning %d: expected suppression on %s, got %q", i, subject, warnings[i].Message)
+		}
+	}
+}
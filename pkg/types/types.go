@@ -1,5 +1,10 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Severity represents finding severity.
 type Severity string
 
@@ -9,11 +14,79 @@ const (
 	SeverityInfo  Severity = "info"
 )
 
+// Valid reports whether s is one of the known severities.
+func (s Severity) Valid() bool {
+	switch s {
+	case SeverityError, SeverityWarn, SeverityInfo:
+		return true
+	}
+	return false
+}
+
+// Location points to a position inside a source file.
+type Location struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// IsZero reports whether the location carries no position.
+func (l Location) IsZero() bool {
+	return l.Line == 0 && l.Column == 0
+}
+
+// String renders the location as file:line:column.
+func (l Location) String() string {
+	if l.File == "" {
+		return fmt.Sprintf("%d:%d", l.Line, l.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+}
+
 // Finding describes a single rule violation or informational message.
 type Finding struct {
-	RuleID   string         `json:"ruleId"`
-	Severity Severity       `json:"severity"`
-	Message  string         `json:"message"`
-	Path     string         `json:"path"`
-	Meta     map[string]any `json:"meta,omitempty"`
+	RuleID   string    `json:"ruleId"`
+	Severity Severity  `json:"severity"`
+	Message  string    `json:"message"`
+	Path     string    `json:"path"`
+	Location *Location `json:"location,omitempty"`
+	// Class identifies the kind of message within a rule, e.g. "no-inbound".
+	Class string `json:"class,omitempty"`
+	// Suppressed marks findings silenced by an inline archlint annotation;
+	// they are reported but never fail a run.
+	Suppressed bool           `json:"suppressed,omitempty"`
+	Meta       map[string]any `json:"meta,omitempty"`
+}
+
+// RuleMetadata describes a rule for reporters and documentation.
+type RuleMetadata struct {
+	ID              string   `json:"id"`
+	Description     string   `json:"description"`
+	DefaultSeverity Severity `json:"defaultSeverity"`
+	Rationale       string   `json:"rationale,omitempty"`
+	DocURL          string   `json:"docUrl,omitempty"`
+	Classes         []string `json:"classes,omitempty"`
+	// ClassSeverities lists the classes reported with a severity other than
+	// DefaultSeverity.
+	ClassSeverities map[string]Severity `json:"classSeverities,omitempty"`
+	Config          []ConfigField       `json:"config,omitempty"`
+}
+
+// ConfigField documents one key a rule accepts in its configuration.
+type ConfigField struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Default     any    `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Fields describes the keys of object values, including objects held in
+	// lists and maps.
+	Fields []ConfigField `json:"fields,omitempty"`
+}
+
+// RulesDocURL is the published rule reference; RuleDocURL links into it.
+const RulesDocURL = "https://github.com/PET-dev-projects/ArchLint/blob/main/docs/rules.md"
+
+// RuleDocURL returns the documentation anchor for a rule ID.
+func RuleDocURL(id string) string {
+	return RulesDocURL + "#" + strings.ToLower(id)
 }
@@ -0,0 +1,5 @@
+package billing
+
+import "example.com/shop/orders/api"
+
+func Charge() api.Order { return api.Order{} }
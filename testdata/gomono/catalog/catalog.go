@@ -0,0 +1,3 @@
+package catalog
+
+func Lookup() string { return "" }
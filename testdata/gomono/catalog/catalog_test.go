@@ -0,0 +1,9 @@
+package catalog
+
+import (
+	"testing"
+
+	"example.com/shop/billing"
+)
+
+func TestLookup(t *testing.T) { _ = billing.Charge() }
@@ -0,0 +1,3 @@
+package api
+
+type Order struct{ ID string }
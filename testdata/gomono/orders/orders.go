@@ -0,0 +1,10 @@
+package orders
+
+import (
+	"fmt"
+
+	"example.com/shop/billing"
+	"example.com/shop/catalog"
+)
+
+func Place() { fmt.Println(billing.Charge(), catalog.Lookup()) }